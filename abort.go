@@ -0,0 +1,72 @@
+package gotest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/kindrid/gotest/should"
+)
+
+// AbortOnFailure makes the first assertion failure abort the whole package
+// run: Context() is canceled, and assertions made afterwards by other tests
+// skip those tests instead of reporting.
+var AbortOnFailure bool
+
+var (
+	abortMu           sync.Mutex
+	abortedBy         string
+	runCtx, cancelRun = context.WithCancel(context.Background())
+)
+
+func init() {
+	should.RequestContext = runCtx
+}
+
+// Context returns the package-wide context. With AbortOnFailure, it is
+// canceled by the first assertion failure, so pass it to anything slow or
+// talking to other systems. RESTHarnesses and Eventually and Consistently
+// use it already.
+func Context() context.Context {
+	return runCtx
+}
+
+// AbortedBy returns the name of the test whose failure aborted the run, or ""
+// if the run hasn't been aborted.
+func AbortedBy() string {
+	abortMu.Lock()
+	defer abortMu.Unlock()
+	return abortedBy
+}
+
+// abort cancels the run because of a failure in test, unless it was already
+// aborted.
+func abort(test string) {
+	abortMu.Lock()
+	defer abortMu.Unlock()
+	if abortedBy == "" {
+		abortedBy = test
+		cancelRun()
+	}
+}
+
+// skipAborted skips t, and returns true, if the run was aborted by another
+// test. The aborting test and its subtests carry on. If t can't be skipped,
+// the note is logged instead and the caller should drop its result.
+func skipAborted(t T) bool {
+	by := AbortedBy()
+	if by == "" {
+		return false
+	}
+	name := t.Name()
+	if name == by || strings.HasPrefix(name, by+"/") {
+		return false
+	}
+	note := fmt.Sprintf("aborted after first failure in %s", by)
+	if s, ok := t.(skipper); ok {
+		s.Skip(note)
+	}
+	t.Logf("%s", note)
+	return true
+}
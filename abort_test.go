@@ -0,0 +1,68 @@
+package gotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kindrid/gotest/should"
+)
+
+// skippingT is a fakeT that can be skipped.
+type skippingT struct {
+	fakeT
+	skipped []string
+}
+
+func (st *skippingT) Skip(args ...interface{}) {
+	st.skipped = append(st.skipped, args[0].(string))
+}
+
+func TestAbortOnFailure(t *testing.T) {
+	AbortOnFailure = true
+	defer func() {
+		AbortOnFailure = false
+		abortedBy = ""
+		runCtx, cancelRun = context.WithCancel(context.Background())
+		should.RequestContext = runCtx
+	}()
+
+	// nothing in this test can use Assert until the run is restored
+	rec := &recorder{}
+	first := &fakeT{name: "TestFirst"}
+	sub := &fakeT{name: "TestFirst/sub"}
+	second := &skippingT{fakeT: fakeT{name: "TestSecond"}}
+	var (
+		before, by string
+		ctxErr     error
+		polled     time.Duration
+	)
+	withReporters(func() {
+		Assert(first, "forced", should.AlwaysPass)
+		before = AbortedBy()
+		Assert(first, "forced", should.AlwaysFail)
+		by = AbortedBy()
+		ctxErr = Context().Err()
+		Assert(sub, "forced", should.AlwaysFail)
+		Assert(second, "forced", should.AlwaysFail)
+
+		start := time.Now()
+		Eventually(second, func() interface{} { return "forced" }, should.AlwaysFail, Within(time.Minute))
+		polled = time.Since(start)
+	}, rec)
+	AbortOnFailure = false
+	abortedBy = ""
+
+	Assert(t, before, should.Equal, "")
+	Assert(t, by, should.Equal, "TestFirst")
+	Assert(t, ctxErr, should.Equal, context.Canceled)
+	Assert(t, should.RequestContext.Err(), should.Equal, context.Canceled)
+	Assert(t, len(rec.results), should.Equal, 3)
+	Assert(t, sub.failed, should.BeTrue)
+	Assert(t, second.failed, should.BeFalse)
+	Assert(t, second.skipped, should.Resemble, []string{
+		"aborted after first failure in TestFirst",
+		"aborted after first failure in TestFirst",
+	})
+	Assert(t, polled, should.BeLessThan, time.Second)
+}
@@ -0,0 +1,106 @@
+package gotest
+
+import (
+	"flag"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kindrid/gotest/should"
+)
+
+// BenchTolerance is how many percent worse than its baseline AssertB lets a
+// benchmark perform.
+var BenchTolerance = 10.0
+
+// BaselinePath derives a benchmark baseline path from a benchmark's name,
+// like GoldenPath, e.g. testdata/BenchmarkParse.baseline.json.
+func BaselinePath(t T) string {
+	return testDataPath(t, ".baseline.json")
+}
+
+// AssertB runs fn b.N times as the body of a benchmark, then asserts that its
+// time, allocations, and bytes per operation are within BenchTolerance
+// percent of the baseline for b (see BaselinePath and should.MatchBaseline).
+// Run the benchmarks with -gotest-update to record baselines.
+//
+//	func BenchmarkParse(b *testing.B) {
+//		gotest.AssertB(b, func() { parse(input) })
+//	}
+//
+// The testing package calls a benchmark with growing b.N until a round lasts
+// -test.benchtime (or, for a count like -benchtime=100x, runs it once more
+// at that count). AssertB only judges that final round, not the rounds that
+// size it.
+func AssertB(b *testing.B, fn func()) {
+	b.ReportAllocs()
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	b.ResetTimer()
+	start := time.Now()
+	for i := 0; i < b.N; i++ {
+		fn()
+	}
+	elapsed := time.Since(start)
+	b.StopTimer()
+	runtime.ReadMemStats(&after)
+	if !finalRound(b.N, elapsed) {
+		return
+	}
+
+	n := float64(b.N)
+	perf := should.Performance{
+		NsPerOp:     float64(elapsed.Nanoseconds()) / n,
+		AllocsPerOp: float64(after.Mallocs-before.Mallocs) / n,
+		BytesPerOp:  float64(after.TotalAlloc-before.TotalAlloc) / n,
+	}
+	conclude(b, evaluate(b, 1, false, perf, should.MatchBaseline, []interface{}{BaselinePath(b), BenchTolerance}))
+}
+
+// finalRound reports whether the testing package stops growing b.N after a
+// round of n calls that took elapsed, going by -test.benchtime as it does.
+func finalRound(n int, elapsed time.Duration) bool {
+	benchtime := "1s"
+	if f := flag.Lookup("test.benchtime"); f != nil {
+		benchtime = f.Value.String()
+	}
+	if strings.HasSuffix(benchtime, "x") {
+		count, err := strconv.Atoi(strings.TrimSuffix(benchtime, "x"))
+		return err != nil || n >= count
+	}
+	d, err := time.ParseDuration(benchtime)
+	return err != nil || elapsed >= d || n >= 1e9
+}
+
+// AssertBenchmark is AssertB for a Test function: it benchmarks fn with
+// testing.Benchmark and judges the result against the baseline for t. Since
+// that takes -test.benchtime (1s by default), it does nothing in -test.short
+// runs; prefer AssertB in Benchmark functions, which only run with -test.bench.
+//
+//	func TestParseSpeed(t *testing.T) {
+//		gotest.AssertBenchmark(t, func() { parse(input) })
+//	}
+func AssertBenchmark(t T, fn func()) {
+	if testing.Short() {
+		return
+	}
+	result := testing.Benchmark(func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			fn()
+		}
+	})
+	if result.N == 0 {
+		t.Errorf("gotest: the benchmark for %s didn't run", t.Name())
+		t.FailNow()
+	}
+	n := float64(result.N)
+	perf := should.Performance{
+		NsPerOp:     float64(result.T.Nanoseconds()) / n,
+		AllocsPerOp: float64(result.MemAllocs) / n,
+		BytesPerOp:  float64(result.MemBytes) / n,
+	}
+	conclude(t, evaluate(t, 1, false, perf, should.MatchBaseline, []interface{}{BaselinePath(t), BenchTolerance}))
+}
@@ -0,0 +1,96 @@
+package gotest
+
+import (
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/kindrid/gotest/should"
+)
+
+func TestAssertB(t *testing.T) {
+	Assert(t, BaselinePath(&fakeT{name: "BenchmarkParse/small"}), should.EndWith, "BenchmarkParse/small.baseline.json")
+
+	dir, err := ioutil.TempDir("", "baseline")
+	Require(t, err, should.BeNil)
+	defer os.RemoveAll(dir)
+	saved := GoldenDir
+	GoldenDir = dir
+	defer func() { GoldenDir = saved }()
+	benchtime := flag.Lookup("test.benchtime").Value.String()
+	defer flag.Set("test.benchtime", benchtime)
+
+	var sink []byte
+	rounds := 0
+	bench := func(benchtime string) []*Result {
+		flag.Set("test.benchtime", benchtime)
+		rounds = 0
+		rec := &recorder{}
+		withReporters(func() {
+			testing.Benchmark(func(b *testing.B) {
+				rounds++
+				AssertB(b, func() { sink = make([]byte, 32) })
+			})
+		}, rec)
+		return rec.results
+	}
+
+	should.UpdateGolden = true
+	results := bench("10x")
+	should.UpdateGolden = false
+	Assert(t, rounds, should.Equal, 2)
+	Require(t, len(results), should.Equal, 1)
+	Assert(t, results[0].Assertion, should.Equal, "should.MatchBaseline")
+	Assert(t, results[0].Actual.(should.Performance).AllocsPerOp, should.BeGreaterThanOrEqualTo, 1)
+
+	// only the round that reaches a timed -benchtime is judged
+	results = bench("20ms")
+	Assert(t, rounds, should.BeGreaterThan, 1)
+	Require(t, len(results), should.Equal, 1)
+	_ = sink
+}
+
+func TestAssertBenchmark(t *testing.T) {
+	dir, err := ioutil.TempDir("", "baseline")
+	Require(t, err, should.BeNil)
+	defer os.RemoveAll(dir)
+	saved := GoldenDir
+	GoldenDir = dir
+	defer func() { GoldenDir = saved }()
+	benchtime := flag.Lookup("test.benchtime").Value.String()
+	defer flag.Set("test.benchtime", benchtime)
+	flag.Set("test.benchtime", "10x")
+
+	var sink []byte
+	work := func() { sink = make([]byte, 32) }
+	ft := &fakeT{name: "TestParseSpeed"}
+	path := BaselinePath(ft)
+
+	should.UpdateGolden = true
+	AssertBenchmark(ft, work)
+	should.UpdateGolden = false
+	stored, err := ioutil.ReadFile(path)
+	Require(t, err, should.BeNil)
+	var baseline should.Performance
+	Require(t, json.Unmarshal(stored, &baseline), should.BeNil)
+	Assert(t, baseline.AllocsPerOp, should.BeGreaterThanOrEqualTo, 1)
+
+	// a baseline without allocations can't be met
+	baseline.AllocsPerOp, baseline.BytesPerOp = 0, 0
+	baseline.NsPerOp *= 1000
+	stored, _ = json.Marshal(baseline)
+	Require(t, ioutil.WriteFile(path, stored, 0644), should.BeNil)
+	// sizing rounds at a timed -benchtime aren't judged
+	flag.Set("test.benchtime", "20ms")
+	rec := &recorder{}
+	withReporters(func() { AssertBenchmark(ft, work) }, rec)
+	Assert(t, ft.failed, should.BeTrue)
+	Require(t, len(rec.results), should.Equal, 1)
+	Assert(t, rec.results[0].Assertion, should.Equal, "should.MatchBaseline")
+	Assert(t, rec.results[0].Passed, should.BeFalse)
+	Assert(t, rec.results[0].Short, should.ContainSubstring, "allocs/op")
+	Assert(t, rec.results[0].Short, should.NotContainSubstring, "ns/op")
+	_ = sink
+}
@@ -0,0 +1,159 @@
+package gotest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kindrid/gotest/debug"
+	"github.com/kindrid/gotest/should"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Check is one assertion read from a check file by LoadChecks. The actual
+// value comes from the Source named by Actual or, if Actual is blank, is
+// Value itself. Assert names a registered assertion (see should.Lookup).
+// Expected values written as KindPrefix and a reflect.Kind's name, e.g.
+// "kind:string", are read as that reflect.Kind, for type checks such as
+// HaveFields'.
+//
+//	# testdata/users.yaml
+//	- name: user has an id
+//	  actual: user
+//	  assert: HaveFields
+//	  expected: [data.id, "kind:string"]
+type Check struct {
+	Name     string        `json:"name" yaml:"name"`
+	Actual   string        `json:"actual" yaml:"actual"`
+	Value    interface{}   `json:"value" yaml:"value"`
+	Assert   string        `json:"assert" yaml:"assert"`
+	Expected []interface{} `json:"expected" yaml:"expected"`
+}
+
+// KindPrefix marks an expected value in a check file as a reflect.Kind.
+const KindPrefix = "kind:"
+
+// kinds maps the names of reflect.Kinds to them.
+var kinds = func() map[string]reflect.Kind {
+	m := make(map[string]reflect.Kind)
+	for k := reflect.Invalid + 1; k <= reflect.UnsafePointer; k++ {
+		m[k.String()] = k
+	}
+	return m
+}()
+
+// Sources provide the actual values for checks, by name.
+type Sources map[string]func() interface{}
+
+// LoadChecks reads a list of Checks from a YAML (.yaml or .yml) or JSON
+// file. Whole numbers are read as ints and other numbers as float64s, and
+// YAML mappings become map[string]interface{} like JSON objects, so values
+// from either kind of file work with the same assertions.
+func LoadChecks(path string) (checks []Check, err error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &checks)
+	default:
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		err = dec.Decode(&checks)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can't read checks from %s: %s", path, err)
+	}
+	for i := range checks {
+		checks[i].Value = checkValue(checks[i].Value)
+		for j, e := range checks[i].Expected {
+			if checks[i].Expected[j], err = checkExpected(e); err != nil {
+				return nil, fmt.Errorf("can't read check %d in %s: %s", i+1, path, err)
+			}
+		}
+	}
+	return checks, nil
+}
+
+// checkExpected converts an expected value like checkValue, and reads
+// KindPrefix values as reflect.Kinds.
+func checkExpected(v interface{}) (interface{}, error) {
+	s, ok := v.(string)
+	if !ok || !strings.HasPrefix(s, KindPrefix) {
+		return checkValue(v), nil
+	}
+	kind, ok := kinds[strings.TrimPrefix(s, KindPrefix)]
+	if !ok {
+		return nil, fmt.Errorf("%q names no reflect.Kind", s)
+	}
+	return kind, nil
+}
+
+// checkValue converts decoded YAML or JSON to the types LoadChecks promises.
+func checkValue(v interface{}) interface{} {
+	switch x := v.(type) {
+	case json.Number:
+		if i, err := x.Int64(); err == nil {
+			return int(i)
+		}
+		f, _ := x.Float64()
+		return f
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(x))
+		for k, e := range x {
+			m[fmt.Sprint(k)] = checkValue(e)
+		}
+		return m
+	case map[string]interface{}:
+		for k, e := range x {
+			x[k] = checkValue(e)
+		}
+	case []interface{}:
+		for i, e := range x {
+			x[i] = checkValue(e)
+		}
+	}
+	return v
+}
+
+// RunChecks runs the checks in the file at path (see LoadChecks), each as a
+// subtest asserting like Assert, with actual values drawn from sources.
+//
+//	gotest.RunChecks(t, "testdata/users.yaml", gotest.Sources{
+//		"user": func() interface{} { return getUser(t, "1") },
+//	})
+func RunChecks(t *testing.T, path string, sources Sources) {
+	pkg := debug.CallerPackage(1)
+	checks, err := LoadChecks(path)
+	if err != nil {
+		t.Fatalf("gotest: %s", err)
+	}
+	for i, check := range checks {
+		check := check
+		name := check.Name
+		if name == "" {
+			name = fmt.Sprintf("check %d", i+1)
+		}
+		t.Run(name, func(t *testing.T) {
+			assertion, ok := should.Lookup(check.Assert)
+			if !ok {
+				t.Fatalf("gotest: %s: no assertion is registered as %q", path, check.Assert)
+			}
+			actual := check.Value
+			if check.Actual != "" {
+				source, ok := sources[check.Actual]
+				if !ok {
+					t.Fatalf("gotest: %s: no source is named %q", path, check.Actual)
+				}
+				actual = source()
+			}
+			conclude(t, evaluateRow(t, pkg, fmt.Sprintf("%s[%s]", filepath.Base(path), name), actual, assertion, check.Expected))
+		})
+	}
+}
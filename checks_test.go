@@ -0,0 +1,71 @@
+package gotest
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/kindrid/gotest/should"
+)
+
+const yamlChecks = `
+- name: status
+  actual: status
+  assert: Equal
+  expected: [200]
+- name: user has fields
+  actual: body
+  assert: HaveFields
+  expected: [data.id, "1", data.age, 42]
+- name: user has typed fields
+  actual: body
+  assert: HaveFields
+  expected: [data.id, "kind:string", data.age, "kind:float64"]
+- value: {count: 3, ratio: 0.5}
+  assert: should.Resemble
+  expected:
+    - {count: 3, ratio: 0.5}
+`
+
+const jsonChecks = `[
+	{"name": "status", "actual": "status", "assert": "BeBetween", "expected": [199, 300]},
+	{"name": "literal", "value": {"n": 1, "f": 1.5}, "assert": "Resemble", "expected": [{"n": 1, "f": 1.5}]}
+]`
+
+func TestRunChecks(t *testing.T) {
+	dir, err := ioutil.TempDir("", "checks")
+	Require(t, err, should.BeNil)
+	defer os.RemoveAll(dir)
+	yamlPath := filepath.Join(dir, "api.yaml")
+	jsonPath := filepath.Join(dir, "api.json")
+	Require(t, ioutil.WriteFile(yamlPath, []byte(yamlChecks), 0644), should.BeNil)
+	Require(t, ioutil.WriteFile(jsonPath, []byte(jsonChecks), 0644), should.BeNil)
+
+	checks, err := LoadChecks(yamlPath)
+	Require(t, err, should.BeNil)
+	Assert(t, len(checks), should.Equal, 4)
+	Assert(t, checks[2].Expected, should.Resemble, []interface{}{"data.id", reflect.String, "data.age", reflect.Float64})
+	Assert(t, checks[0].Expected, should.Resemble, []interface{}{200})
+	Assert(t, checks[3].Value, should.Resemble, map[string]interface{}{"count": 3, "ratio": 0.5})
+
+	checks, err = LoadChecks(jsonPath)
+	Require(t, err, should.BeNil)
+	Assert(t, checks[1].Value, should.Resemble, map[string]interface{}{"n": 1, "f": 1.5})
+
+	_, err = LoadChecks(filepath.Join(dir, "missing.yaml"))
+	Assert(t, err, should.NotBeNil)
+
+	badPath := filepath.Join(dir, "bad.yaml")
+	Require(t, ioutil.WriteFile(badPath, []byte(`[{assert: HaveFields, expected: [id, "kind:text"]}]`), 0644), should.BeNil)
+	_, err = LoadChecks(badPath)
+	Assert(t, err, should.NotBeNil)
+
+	sources := Sources{
+		"status": func() interface{} { return 200 },
+		"body":   func() interface{} { return `{"data": {"id": "1", "age": 42}}` },
+	}
+	t.Run("yaml", func(t *testing.T) { RunChecks(t, yamlPath, sources) })
+	t.Run("json", func(t *testing.T) { RunChecks(t, jsonPath, sources) })
+}
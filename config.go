@@ -0,0 +1,173 @@
+package gotest
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Config holds the settings that otherwise come from the package globals
+// (Verbosity, StackDepth, StackLevel, FailFast, and the MaxInspect limits) in
+// effect for a single test; see Settings. A MaxInspect limit of 0 or less
+// means no limit.
+type Config struct {
+	Verbosity  int
+	StackDepth int
+	StackLevel int
+	FailFast   bool
+
+	MaxInspectBytes    int
+	MaxInspectDepth    int
+	MaxInspectElements int
+	MaxInspectString   int
+}
+
+// Overrides changes some of the settings in Config for a test; see With. Nil
+// fields inherit the enclosing test's setting and, at the top, the
+// flag-driven globals. Int and Bool make the pointers.
+//
+//	gotest.With(t, gotest.Overrides{Verbosity: gotest.Int(gotest.Short), FailFast: gotest.Bool(false)})
+type Overrides struct {
+	Verbosity  *int
+	StackDepth *int
+	StackLevel *int
+	FailFast   *bool
+
+	MaxInspectBytes    *int
+	MaxInspectDepth    *int
+	MaxInspectElements *int
+	MaxInspectString   *int
+}
+
+// Int returns a pointer to n, for Overrides.
+func Int(n int) *int {
+	return &n
+}
+
+// Bool returns a pointer to b, for Overrides.
+func Bool(b bool) *bool {
+	return &b
+}
+
+// testConfigs maps test names to the Overrides attached to them.
+var testConfigs sync.Map
+
+// cleaner is the part of *testing.T (Go 1.14+) used to forget Overrides.
+type cleaner interface {
+	Cleanup(func())
+}
+
+// With attaches over to t, so Assert and friends use its settings instead of
+// the package globals for t and its subtests. Unlike changing the globals, it
+// is safe with t.Parallel(). Given a Group's T, it applies to that group only.
+//
+//	gotest.With(t, gotest.Overrides{Verbosity: gotest.Int(gotest.Actuals)})
+//
+// With takes Overrides rather than a whole Config, so that a test can change
+// one setting, even to its zero value, and inherit the rest; Settings gives
+// the resulting Config.
+func With(t T, over Overrides) {
+	if g, ok := t.(*group); ok {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		g.over = over
+		return
+	}
+	name := t.Name()
+	testConfigs.Store(name, over)
+	if c, ok := t.(cleaner); ok {
+		c.Cleanup(func() { testConfigs.Delete(name) })
+	}
+}
+
+// Defaults returns the flag-driven Config held in the package globals.
+func Defaults() Config {
+	return Config{
+		Verbosity:  Verbosity,
+		StackDepth: StackDepth,
+		StackLevel: StackLevel,
+		FailFast:   FailFast,
+
+		MaxInspectBytes:    MaxInspectBytes,
+		MaxInspectDepth:    MaxInspectDepth,
+		MaxInspectElements: MaxInspectElements,
+		MaxInspectString:   MaxInspectString,
+	}
+}
+
+// Settings resolves the Config in effect for t: Overrides attached to t or
+// its parent tests, falling back to Defaults().
+func Settings(t T) Config {
+	result := Defaults()
+	if t == nil {
+		return result
+	}
+	if g, ok := t.(*group); ok {
+		g.mu.Lock()
+		over := g.over
+		g.mu.Unlock()
+		return Settings(g.parent).merge(over)
+	}
+	// apply ancestors' overrides from the top-level test down
+	segments := strings.Split(t.Name(), "/")
+	for i := range segments {
+		if v, ok := testConfigs.Load(strings.Join(segments[:i+1], "/")); ok {
+			result = result.merge(v.(Overrides))
+		}
+	}
+	return result
+}
+
+// merge overlays the non-nil fields of over onto cfg.
+func (cfg Config) merge(over Overrides) Config {
+	setInt := func(dst *int, src *int) {
+		if src != nil {
+			*dst = *src
+		}
+	}
+	setInt(&cfg.Verbosity, over.Verbosity)
+	setInt(&cfg.StackDepth, over.StackDepth)
+	setInt(&cfg.StackLevel, over.StackLevel)
+	if over.FailFast != nil {
+		cfg.FailFast = *over.FailFast
+	}
+	setInt(&cfg.MaxInspectBytes, over.MaxInspectBytes)
+	setInt(&cfg.MaxInspectDepth, over.MaxInspectDepth)
+	setInt(&cfg.MaxInspectElements, over.MaxInspectElements)
+	setInt(&cfg.MaxInspectString, over.MaxInspectString)
+	return cfg
+}
+
+// Vocal returns true if cfg.Verbosity >= minLevel.
+func (cfg Config) Vocal(minLevel int) bool {
+	return cfg.Verbosity >= minLevel
+}
+
+// Sprintv formats a string if cfg.Verbosity >= minLevel, otherwise returns "".
+func (cfg Config) Sprintv(minLevel int, format string, args ...interface{}) string {
+	if !cfg.Vocal(minLevel) {
+		return ""
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// Inspectv returns a detailed introspection of objects if cfg.Verbosity >=
+// minLevel, shortened to cfg's MaxInspect limits.
+func (cfg Config) Inspectv(minLevel int, label string, inspected ...interface{}) string {
+	if !cfg.Vocal(minLevel) {
+		return ""
+	}
+	return cfg.inspect(label, inspected...)
+}
+
+// inspect does the work of Inspectv regardless of verbosity.
+func (cfg Config) inspect(label string, inspected ...interface{}) (result string) {
+	if label != "" {
+		result = fmt.Sprintf("%s: \n", label)
+	}
+	s := cfg.shortener()
+	for _, x := range inspected {
+		result += s.inspect(x)
+	}
+	return
+}
@@ -0,0 +1,58 @@
+package gotest
+
+import (
+	"testing"
+
+	"github.com/kindrid/gotest/should"
+)
+
+func TestConfig(t *testing.T) {
+	Assert(t, Settings(t), should.Resemble, Defaults())
+
+	t.Run("group", func(t *testing.T) {
+		t.Run("loud", func(t *testing.T) {
+			t.Parallel()
+			With(t, Overrides{Verbosity: Int(Actuals), FailFast: Bool(true)})
+			cfg := Settings(t)
+			Assert(t, cfg.Verbosity, should.Equal, Actuals)
+			Assert(t, cfg.FailFast, should.BeTrue)
+			Assert(t, cfg.Sprintv(Long, "x"), should.Equal, "x")
+			t.Run("inherits", func(t *testing.T) {
+				With(t, Overrides{StackDepth: Int(3)})
+				cfg := Settings(t)
+				Assert(t, cfg.Verbosity, should.Equal, Actuals)
+				Assert(t, cfg.StackDepth, should.Equal, 3)
+			})
+		})
+		t.Run("quiet", func(t *testing.T) {
+			t.Parallel()
+			With(t, Overrides{Verbosity: Int(Silent)})
+			cfg := Settings(t)
+			Assert(t, cfg.Verbosity, should.Equal, Silent)
+			Assert(t, cfg.FailFast, should.BeFalse)
+			Assert(t, cfg.Sprintv(Short, "x"), should.Equal, "")
+		})
+	})
+
+	// Attached configs are forgotten when their tests finish.
+	Assert(t, Settings(&fakeT{name: t.Name() + "/group/loud"}), should.Resemble, Defaults())
+
+	// Assert honors the attached config.
+	ft := &fakeT{name: "TestConfig/fake"}
+	With(ft, Overrides{FailFast: Bool(true)})
+	defer ft.finish()
+	Assert(ft, "forced", should.AlwaysFail)
+	Assert(t, ft.stopped, should.BeTrue)
+
+	// Overrides can clear settings, too.
+	defer func(v, d int, f bool) { Verbosity, StackDepth, FailFast = v, d, f }(Verbosity, StackDepth, FailFast)
+	Verbosity, StackDepth, FailFast = Long, 3, true
+	quiet := &fakeT{name: "TestConfig/quiet"}
+	With(quiet, Overrides{Verbosity: Int(Short), StackDepth: Int(0), FailFast: Bool(false)})
+	defer quiet.finish()
+	cfg := Settings(quiet)
+	Assert(t, cfg.Verbosity, should.Equal, Short)
+	Assert(t, cfg.StackDepth, should.Equal, 0)
+	Assert(t, cfg.FailFast, should.BeFalse)
+	Assert(t, cfg.StackLevel, should.Equal, StackLevel)
+}
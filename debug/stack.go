@@ -2,25 +2,61 @@ package debug
 
 import (
 	"fmt"
+	"net/url"
 	"path"
 	"runtime"
 	"strings"
 )
 
-// // CallerInfo gives info about the current call stack.
-// func CallerInfo(depth int) (msg, fileName string, fileLine int) {
-// 	programCoun, fileName, fileLine, ok := runtime.Caller(depth)
-// 	if ok {
-// 		msg = fmt.Sprintf("%s:%d", fileName, fileLine)
-// 	}
-// 	return
-// }
-//
-// // CallerSimple gives a single string with condensed information about the current call stack.
-// func CallerSimple(depth int) string {
-// 	msg, _, _ := CallerInfo(depth)
-// 	return msg
-// }
+// CallerInfo gives info about the current call stack. depth counts frames the
+// same way runtime.Caller does: 0 is CallerInfo's caller.
+func CallerInfo(depth int) (msg, fileName string, fileLine int) {
+	_, fileName, fileLine, ok := runtime.Caller(depth + 1)
+	if ok {
+		msg = fmt.Sprintf("%s:%d", fileName, fileLine)
+	}
+	return
+}
+
+// CallerSimple gives a single short "file.go:line" string locating a caller.
+func CallerSimple(depth int) string {
+	_, fileName, fileLine := CallerInfo(depth + 1)
+	if fileName == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d", path.Base(fileName), fileLine)
+}
+
+// CallerPackage gives the import path of the package holding a caller's
+// function, e.g. "github.com/kindrid/gotest". Functions in external test
+// packages (foo_test) are reported as part of the package they test.
+func CallerPackage(depth int) string {
+	pc, _, _, ok := runtime.Caller(depth + 1)
+	if !ok {
+		return ""
+	}
+	fn := runtime.FuncForPC(pc)
+	if fn == nil {
+		return ""
+	}
+	return packageOf(fn.Name())
+}
+
+// packageOf gives the import path in a function's full name, such as
+// "gopkg.in/yaml%2ev2.(*Decoder).Decode". Dots in the last element of the
+// path are escaped in these names, so the path ends at the first dot after
+// the last slash; the rest (receiver, method, closure) may hold more dots.
+func packageOf(funcName string) string {
+	name := funcName
+	slash := strings.LastIndex(name, "/")
+	if dot := strings.Index(name[slash+1:], "."); dot >= 0 {
+		name = name[:slash+1+dot]
+	}
+	if unescaped, err := url.PathUnescape(name); err == nil {
+		name = unescaped
+	}
+	return strings.TrimSuffix(name, "_test")
+}
 
 const minSkip = 1
 
@@ -1,7 +1,42 @@
 package debug
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestStackTraces(t *testing.T) {
 
 }
+
+func TestCallerInfo(t *testing.T) {
+	msg, file, line := CallerInfo(0)
+	if !strings.HasSuffix(file, "stack_test.go") || line == 0 {
+		t.Errorf("CallerInfo(0) should locate its caller, got %q", msg)
+	}
+	if simple := CallerSimple(0); !strings.HasPrefix(simple, "stack_test.go:") {
+		t.Errorf("CallerSimple(0) should give a short location, got %q", simple)
+	}
+}
+
+func TestCallerPackage(t *testing.T) {
+	if pkg := CallerPackage(0); pkg != "github.com/kindrid/gotest/debug" {
+		t.Errorf("CallerPackage(0) should give this package's import path, got %q", pkg)
+	}
+	func() {
+		if pkg := CallerPackage(1); pkg != "github.com/kindrid/gotest/debug" {
+			t.Errorf("CallerPackage(1) should see through closures, got %q", pkg)
+		}
+	}()
+	for name, want := range map[string]string{
+		"gopkg.in/yaml%2ev2.Unmarshal":                 "gopkg.in/yaml.v2",
+		"gopkg.in/yaml%2ev2.(*Decoder).Decode":         "gopkg.in/yaml.v2",
+		"github.com/kindrid/gotest.(*group).Error":     "github.com/kindrid/gotest",
+		"github.com/kindrid/gotest_test.TestX.func1.2": "github.com/kindrid/gotest",
+		"main.main": "main",
+	} {
+		if pkg := packageOf(name); pkg != want {
+			t.Errorf("packageOf(%q) should be %q, got %q", name, want, pkg)
+		}
+	}
+}
@@ -0,0 +1,275 @@
+package gotest
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/kindrid/gotest/should"
+)
+
+// maxDiffCells bounds the work of a line diff. Inputs whose differing middle
+// sections would need more comparisons than this get no diff.
+const maxDiffCells = 1 << 20
+
+// ANSI escapes used to color diffs.
+const (
+	ansiRed   = "\x1b[31m"
+	ansiGreen = "\x1b[32m"
+	ansiCyan  = "\x1b[36m"
+	ansiReset = "\x1b[0m"
+)
+
+// diffOp is one line of a line diff: kept (' '), removed ('-'), or added
+// ('+').
+type diffOp struct {
+	kind byte
+	text string
+}
+
+// UnifiedDiff returns a unified diff turning expected into actual with
+// context unchanged lines around each change, or "" if they're the same or
+// too large to compare.
+func UnifiedDiff(expected, actual string, context int) string {
+	a, b := splitLines(expected), splitLines(actual)
+	ops := diffLines(a, b)
+	if ops == nil {
+		return ""
+	}
+	if context < 0 {
+		context = 0
+	}
+
+	// aPos and bPos count the lines of each side before each op
+	aPos := make([]int, len(ops)+1)
+	bPos := make([]int, len(ops)+1)
+	var changes []int
+	for i, op := range ops {
+		aPos[i+1], bPos[i+1] = aPos[i], bPos[i]
+		if op.kind != '+' {
+			aPos[i+1]++
+		}
+		if op.kind != '-' {
+			bPos[i+1]++
+		}
+		if op.kind != ' ' {
+			changes = append(changes, i)
+		}
+	}
+	if len(changes) == 0 {
+		return ""
+	}
+
+	out := &strings.Builder{}
+	fmt.Fprintf(out, "--- expected\n+++ actual\n")
+	for first := 0; first < len(changes); {
+		// extend the hunk while the next change is within reach of its context
+		last := first
+		for last+1 < len(changes) && changes[last+1]-changes[last] <= 2*context+1 {
+			last++
+		}
+		start := changes[first] - context
+		if start < 0 {
+			start = 0
+		}
+		end := changes[last] + context + 1
+		if end > len(ops) {
+			end = len(ops)
+		}
+		fmt.Fprintf(out, "@@ -%s +%s @@\n",
+			hunkRange(aPos[start], aPos[end]-aPos[start]),
+			hunkRange(bPos[start], bPos[end]-bPos[start]))
+		for _, op := range ops[start:end] {
+			fmt.Fprintf(out, "%c%s\n", op.kind, op.text)
+		}
+		first = last + 1
+	}
+	return out.String()
+}
+
+// hunkRange formats one side of a hunk header. before is the number of lines
+// preceding the hunk.
+func hunkRange(before, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", before)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", before+1)
+	}
+	return fmt.Sprintf("%d,%d", before+1, count)
+}
+
+// splitLines splits text into lines, ignoring one trailing newline.
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// diffLines lines up a and b by their longest common subsequence. It returns
+// nil if that would take more than maxDiffCells comparisons.
+func diffLines(a, b []string) (ops []diffOp) {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	am, bm := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	n, m := len(am), len(bm)
+	if (n+1)*(m+1) > maxDiffCells {
+		return nil
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of am[i:] and bm[j:]
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			switch {
+			case am[i] == bm[j]:
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	ops = make([]diffOp, 0, len(a)+len(b))
+	for _, line := range a[:prefix] {
+		ops = append(ops, diffOp{' ', line})
+	}
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case am[i] == bm[j]:
+			ops = append(ops, diffOp{' ', am[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, diffOp{'-', am[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{'+', bm[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		ops = append(ops, diffOp{'-', am[i]})
+	}
+	for ; j < m; j++ {
+		ops = append(ops, diffOp{'+', bm[j]})
+	}
+	for _, line := range a[len(a)-suffix:] {
+		ops = append(ops, diffOp{' ', line})
+	}
+	return ops
+}
+
+// equalityFailures mark the failures of the smartystreets assertions that
+// compare expected and actual for equality, such as should.Equal and
+// should.Resemble.
+var equalityFailures = []string{"\n(Should be equal", "\n(Should resemble)"}
+
+// failureDiff diffs the values a failure says were compared: the Expected and
+// Actual it carries (see should.Failure), or else, for an equality failure,
+// the assertion's only expected value and actual. Other failures get no diff;
+// one of a substring or a pattern against the actual value would mislead.
+func failureDiff(fail string, f should.Failure, actual interface{}, expected []interface{}, context int) string {
+	if f.Expected != nil && f.Actual != nil {
+		return diffValues(f.Expected, f.Actual, context)
+	}
+	if len(expected) != 1 {
+		return ""
+	}
+	for _, marker := range equalityFailures {
+		if strings.Contains(fail, marker) {
+			return diffValues(expected[0], actual, context)
+		}
+	}
+	return ""
+}
+
+// diffValues diffs the text of expected and actual. JSON text and containers
+// that should.ParseJSON understands are compared as pretty-printed JSON; other
+// strings and byte slices are compared as they are. Single-line values and
+// anything else get no diff, as the failure message already says enough.
+func diffValues(expected, actual interface{}, context int) string {
+	e, eOK := diffableJSON(expected)
+	a, aOK := diffableJSON(actual)
+	if !eOK || !aOK {
+		e, eOK = diffableText(expected)
+		a, aOK = diffableText(actual)
+	}
+	if !eOK || !aOK || (!strings.Contains(e, "\n") && !strings.Contains(a, "\n")) {
+		return ""
+	}
+	return UnifiedDiff(e, a, context)
+}
+
+// diffableJSON pretty-prints v if it is or holds JSON.
+func diffableJSON(v interface{}) (string, bool) {
+	explorer, err := should.ParseJSON(v)
+	if err != nil || decodedJSON(v) {
+		return "", false
+	}
+	return explorer.String(), true
+}
+
+// diffableText gives the text of strings and byte slices.
+func diffableText(v interface{}) (string, bool) {
+	switch x := v.(type) {
+	case string:
+		return x, true
+	case *string:
+		if x != nil {
+			return *x, true
+		}
+	case []byte:
+		return string(x), true
+	}
+	return "", false
+}
+
+// colorDiff adds ANSI colors to a unified diff.
+func colorDiff(diff string) string {
+	lines := strings.Split(diff, "\n")
+	for i, line := range lines {
+		color := ""
+		switch {
+		case strings.HasPrefix(line, "---"), strings.HasPrefix(line, "+++"):
+		case strings.HasPrefix(line, "@@"):
+			color = ansiCyan
+		case strings.HasPrefix(line, "-"):
+			color = ansiRed
+		case strings.HasPrefix(line, "+"):
+			color = ansiGreen
+		}
+		if color != "" {
+			lines[i] = color + line + ansiReset
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// useColor decides whether to color output according to Color.
+func useColor() bool {
+	switch Color {
+	case "always":
+		return true
+	case "never":
+		return false
+	}
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	info, err := os.Stdout.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
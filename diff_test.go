@@ -0,0 +1,96 @@
+package gotest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kindrid/gotest/should"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	expected := "a\nb\nc\nd\ne\nf\ng\nh\n"
+	actual := "a\nb\nC\nd\ne\nf\ng\nh\ni\n"
+	Assert(t, UnifiedDiff(expected, expected, 3), should.Equal, "")
+	Assert(t, UnifiedDiff(expected, actual, 1), should.Equal, strings.Join([]string{
+		"--- expected",
+		"+++ actual",
+		"@@ -2,3 +2,3 @@",
+		" b",
+		"-c",
+		"+C",
+		" d",
+		"@@ -8 +8,2 @@",
+		" h",
+		"+i",
+		"",
+	}, "\n"))
+
+	// changes within reach of each other's context share a hunk
+	Assert(t, UnifiedDiff(expected, actual, 3), should.StartWith, "--- expected\n+++ actual\n@@ -1,8 +1,9 @@\n a\n")
+	Assert(t, strings.Count(UnifiedDiff(expected, actual, 3), "@@ "), should.Equal, 1)
+}
+
+func TestDiffValues(t *testing.T) {
+	// single lines say enough in the failure message
+	Assert(t, diffValues("abc", "abd", 3), should.Equal, "")
+	Assert(t, diffValues(1, 2, 3), should.Equal, "")
+
+	Assert(t, diffValues("x\ny\n", []byte("x\nz\n"), 3), should.ContainSubstring, "-y\n+z\n")
+
+	// JSON is pretty-printed, so key order and spacing don't matter
+	diff := diffValues(`{"b": 2, "a": 1}`, `{"a":1,"b":3}`, 0)
+	Assert(t, diff, should.ContainSubstring, "-  \"b\": 2\n+  \"b\": 3\n")
+	Assert(t, diff, should.NotContainSubstring, `"a"`)
+
+	// decoded JSON isn't text
+	Assert(t, diffValues(map[string]interface{}{"a": "x\ny"}, map[string]interface{}{"a": "x\nz"}, 3), should.Equal, "")
+}
+
+func TestColorDiff(t *testing.T) {
+	colored := colorDiff("--- expected\n+++ actual\n@@ -1 +1 @@\n-a\n+b\n")
+	Assert(t, colored, should.Equal, "--- expected\n+++ actual\n"+
+		ansiCyan+"@@ -1 +1 @@"+ansiReset+"\n"+
+		ansiRed+"-a"+ansiReset+"\n"+
+		ansiGreen+"+b"+ansiReset+"\n")
+
+	saved := Color
+	defer func() { Color = saved }()
+	Color = "always"
+	Assert(t, useColor(), should.BeTrue)
+	Color = "never"
+	Assert(t, useColor(), should.BeFalse)
+}
+
+func TestFailureDiffs(t *testing.T) {
+	rec := &recorder{}
+	ft := &fakeT{name: "TestFailureDiffs"}
+	With(ft, Overrides{Verbosity: Int(Long)})
+	defer ft.finish()
+	withReporters(func() {
+		Assert(ft, "one\ntwo\nthree", should.Equal, "one\n2\nthree")
+	}, rec, TextReporter{})
+	Assert(t, rec.results[0].Diff, should.Equal, "--- expected\n+++ actual\n@@ -1,3 +1,3 @@\n one\n-2\n+two\n three\n")
+	Assert(t, ft.errors[0], should.ContainSubstring, "DIFF:\n--- expected")
+
+	// only equality assertions get diffs
+	rec.results = nil
+	withReporters(func() {
+		Assert(ft, "one\ntwo", should.ContainSubstring, "one\n2")
+	}, rec)
+	Assert(t, rec.results[0].Diff, should.Equal, "")
+
+	// failures that carry what they compared get a diff of that
+	type doc struct{ Text string }
+	rec.results = nil
+	withReporters(func() {
+		Assert(ft, doc{"one\ntwo"}, should.MatchStruct(doc{"one\n2"}))
+	}, rec)
+	Assert(t, rec.results[0].Diff, should.Equal, "--- expected\n+++ actual\n@@ -1,2 +1,2 @@\n one\n-2\n+two\n")
+
+	// below Long, no diff is computed
+	rec.results = nil
+	withReporters(func() {
+		Assert(&fakeT{}, "one\ntwo", should.Equal, "one\n2")
+	}, rec)
+	Assert(t, rec.results[0].Diff, should.Equal, "")
+}
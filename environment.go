@@ -0,0 +1,117 @@
+package gotest
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// ConfigFileName is the settings file RegisterFlags looks for, starting in the
+// package directory and walking up towards the root.
+const ConfigFileName = ".gotest.yaml"
+
+// EnvPrefix starts the names of environment variables holding gotest
+// settings. The rest of the name is the setting's flag name (without the flag
+// prefix) in upper case with dashes turned to underscores, e.g.
+// GOTEST_VERBOSITY or GOTEST_REPORT_JSON.
+const EnvPrefix = "GOTEST_"
+
+// presetFlags holds the values applySettingSources gave flags, which a re-run
+// picks up again from the same environment and settings file.
+var presetFlags = map[string]string{}
+
+// applySettingSources sets the registered flags named prefix+name from
+// ConfigFileName, then from the environment. Command-line flags are parsed
+// later, so they still win.
+func applySettingSources(prefix string, names []string) {
+	fileValues, path, err := readConfigFile()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gotest: ignoring %s: %s\n", path, err)
+	}
+	known := make(map[string]bool)
+	for _, name := range names {
+		known[name] = true
+		if value, ok := fileValues[name]; ok {
+			setSetting(prefix+name, value, path)
+		}
+		if value, ok := os.LookupEnv(envName(name)); ok {
+			setSetting(prefix+name, value, "$"+envName(name))
+		}
+	}
+	for name := range fileValues {
+		if !known[name] {
+			fmt.Fprintf(os.Stderr, "gotest: ignoring unknown setting %q in %s\n", name, path)
+		}
+	}
+}
+
+// envName gives the environment variable for a setting.
+func envName(name string) string {
+	return EnvPrefix + strings.ToUpper(strings.Replace(name, "-", "_", -1))
+}
+
+// setSetting sets a flag, warning about values it can't use.
+func setSetting(flagName, value, source string) {
+	if err := flag.Set(flagName, value); err != nil {
+		fmt.Fprintf(os.Stderr, "gotest: ignoring %s=%q from %s: %s\n", flagName, value, source, err)
+		return
+	}
+	presetFlags[flagName] = flag.Lookup(flagName).Value.String()
+}
+
+// findConfigFile looks for ConfigFileName in the working directory (the
+// package directory under `go test`) and each of its parents.
+func findConfigFile() string {
+	dir, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	for {
+		path := filepath.Join(dir, ConfigFileName)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// readConfigFile reads the nearest ConfigFileName, a YAML mapping of setting
+// names to single values, e.g.
+//
+//	verbosity: 2
+//	failfast: true
+//	report-json: results.jsonl  # comments are fine
+func readConfigFile() (values map[string]string, path string, err error) {
+	values = make(map[string]string)
+	if path = findConfigFile(); path == "" {
+		return
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, path, err
+	}
+	var settings map[string]interface{}
+	if err = yaml.Unmarshal(data, &settings); err != nil {
+		return nil, path, err
+	}
+	for key, value := range settings {
+		switch value.(type) {
+		case nil:
+			values[key] = ""
+		case map[interface{}]interface{}, []interface{}:
+			return nil, path, fmt.Errorf("setting %q needs a single value", key)
+		default:
+			values[key] = fmt.Sprint(value)
+		}
+	}
+	return
+}
@@ -0,0 +1,48 @@
+package gotest
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kindrid/gotest/should"
+)
+
+func TestSettingSources(t *testing.T) {
+	root, err := ioutil.TempDir("", "gotest")
+	Require(t, err, should.BeNil)
+	defer os.RemoveAll(root)
+	pkgDir := filepath.Join(root, "pkg", "sub")
+	Require(t, os.MkdirAll(pkgDir, 0755), should.BeNil)
+	config := "# settings for every package\nverbosity: 3\ndepth: 4 # trailing comment\nreport-json: \"a#b.jsonl\"\nreport-junit: it's here.xml\n"
+	Require(t, ioutil.WriteFile(filepath.Join(root, ConfigFileName), []byte(config), 0644), should.BeNil)
+
+	wd, _ := os.Getwd()
+	Require(t, os.Chdir(pkgDir), should.BeNil)
+	defer os.Chdir(wd)
+
+	// The file is found by walking up from the package directory.
+	values, path, err := readConfigFile()
+	Assert(t, err, should.BeNil)
+	Assert(t, filepath.Base(path), should.Equal, ConfigFileName)
+	Assert(t, values, should.Resemble, map[string]string{"verbosity": "3", "depth": "4", "report-json": "a#b.jsonl", "report-junit": "it's here.xml"})
+
+	// The environment beats the file and flags beat both.
+	verbosity := flag.Int("envtest-verbosity", 0, "")
+	depth := flag.Int("envtest-depth", 0, "")
+	report := flag.String("envtest-report-json", "", "")
+	os.Setenv("GOTEST_DEPTH", "7")
+	defer os.Unsetenv("GOTEST_DEPTH")
+	applySettingSources("envtest-", []string{"verbosity", "depth", "report-json"})
+	Assert(t, *verbosity, should.Equal, 3)
+	Assert(t, *depth, should.Equal, 7)
+	Assert(t, *report, should.Equal, "a#b.jsonl")
+
+	// Malformed files are rejected as a whole.
+	Require(t, ioutil.WriteFile(filepath.Join(root, ConfigFileName), []byte("nested:\n  verbosity: 3\n"), 0644), should.BeNil)
+	values, _, err = readConfigFile()
+	Assert(t, err, should.NotBeNil)
+	Assert(t, values, should.BeEmpty)
+}
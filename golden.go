@@ -0,0 +1,56 @@
+package gotest
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/kindrid/gotest/should"
+)
+
+// GoldenDir holds the golden files used by Golden.
+var GoldenDir = "testdata"
+
+// unsafePathChars matches characters we keep out of golden file names.
+var unsafePathChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// GoldenPath derives a golden file path from a test's name, with one directory
+// per level of subtest, e.g. testdata/TestUsers/get_one.golden.
+func GoldenPath(t T) string {
+	return testDataPath(t, ".golden")
+}
+
+// SnapshotPath derives a snapshot path from a test's name, like GoldenPath,
+// e.g. testdata/TestUsers/get_one.snapshot.json.
+func SnapshotPath(t T) string {
+	return testDataPath(t, ".snapshot.json")
+}
+
+// testDataPath turns a test's name into a path within GoldenDir.
+func testDataPath(t T, ext string) string {
+	segments := strings.Split(t.Name(), "/")
+	for i, s := range segments {
+		segments[i] = unsafePathChars.ReplaceAllString(s, "_")
+	}
+	return filepath.Join(GoldenDir, filepath.Join(segments...)+ext)
+}
+
+// Golden asserts that actual matches the golden file for t (see GoldenPath
+// and should.MatchGolden). Run the tests with -gotest-update to create or
+// rewrite golden files.
+func Golden(t T, actual interface{}) {
+	conclude(t, evaluate(t, 1, false, actual, should.MatchGolden, []interface{}{GoldenPath(t)}))
+}
+
+// Snapshot asserts that actual JSON, normalized by rules, matches the
+// snapshot for t (see SnapshotPath and should.MatchSnapshot). Run the tests
+// with -gotest-update to create or rewrite snapshots.
+//
+//	gotest.Snapshot(t, rsp.Body, should.Placeholder("data.id", "<id>"))
+func Snapshot(t T, actual interface{}, rules ...should.Normalizer) {
+	expected := []interface{}{SnapshotPath(t)}
+	for _, rule := range rules {
+		expected = append(expected, rule)
+	}
+	conclude(t, evaluate(t, 1, false, actual, should.MatchSnapshot, expected))
+}
@@ -0,0 +1,39 @@
+package gotest
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kindrid/gotest/should"
+)
+
+func TestGolden(t *testing.T) {
+	Assert(t, GoldenPath(&fakeT{name: "TestUsers/get one?/#01"}), should.Equal,
+		filepath.Join("testdata", "TestUsers", "get_one_", "_01.golden"))
+
+	dir, err := ioutil.TempDir("", "golden")
+	Require(t, err, should.BeNil)
+	defer os.RemoveAll(dir)
+	saved := GoldenDir
+	GoldenDir = dir
+	defer func() { GoldenDir = saved }()
+
+	t.Run("round trip", func(t *testing.T) {
+		should.UpdateGolden = true
+		Golden(t, `{"id": "1"}`)
+		should.UpdateGolden = false
+		Golden(t, `{ "id" : "1" }`)
+		_, err := os.Stat(filepath.Join(dir, "TestGolden", "round_trip.golden"))
+		Assert(t, err, should.BeNil)
+	})
+
+	t.Run("snapshot", func(t *testing.T) {
+		should.UpdateGolden = true
+		Snapshot(t, `{"id": "1", "name": "x"}`, should.Placeholder("id", "<id>"))
+		should.UpdateGolden = false
+		Snapshot(t, `{"id": "2", "name": "x"}`, should.Placeholder("id", "<id>"))
+		Assert(t, SnapshotPath(t), should.EndWith, filepath.Join("TestGolden", "snapshot.snapshot.json"))
+	})
+}
@@ -0,0 +1,189 @@
+package gotest
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kindrid/gotest/debug"
+	"github.com/kindrid/gotest/should"
+)
+
+// Group runs fn with a T that collects assertion failures instead of
+// reporting them one by one. When fn returns, the group reports once: a
+// numbered list of the failures and a single copy of the value(s) under test.
+// FailFast is honored at the end of the group rather than at the first
+// failure. Reporters get that one combined Result, named "gotest.Group",
+// rather than a Result per assertion, though AssertionSummary counts each.
+// The group's T may be used from several goroutines.
+//
+//	gotest.Group(t, func(g gotest.T) {
+//		gotest.Assert(g, doc, should.HaveFields, "id", reflect.String)
+//		gotest.Assert(g, doc, should.HaveFields, "name", reflect.String)
+//	})
+func Group(t T, fn func(g T)) {
+	g := &group{
+		parent:   t,
+		location: debug.CallerSimple(1),
+		calls:    debug.ShortStack(3, 10),
+	}
+	defer g.close()
+	fn(g)
+}
+
+// group implements T by recording what assertions and direct calls report.
+type group struct {
+	parent   T
+	location string
+	calls    string
+
+	mu      sync.Mutex
+	results []*Result
+	closed  bool
+	over    Overrides // attached by With
+}
+
+// Error records a failure reported directly on the group's T.
+func (g *group) Error(args ...interface{}) {
+	g.record(fmt.Sprint(args...))
+}
+
+// Errorf records a failure reported directly on the group's T.
+func (g *group) Errorf(format string, args ...interface{}) {
+	g.record(fmt.Sprintf(format, args...))
+}
+
+// Fail records an unexplained failure.
+func (g *group) Fail() {
+	g.record("Fail() called within group")
+}
+
+// FailNow reports the group so far and stops the test.
+func (g *group) FailNow() {
+	g.close()
+	g.parent.FailNow()
+}
+
+// Logf passes log messages straight through.
+func (g *group) Logf(format string, args ...interface{}) {
+	g.parent.Logf(format, args...)
+}
+
+// Name is the enclosing test's name.
+func (g *group) Name() string {
+	return g.parent.Name()
+}
+
+// add collects the result of an assertion made within the group.
+func (g *group) add(r *Result) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.results = append(g.results, r)
+}
+
+// record collects a failure that didn't come from an assertion.
+func (g *group) record(msg string) {
+	r := &Result{
+		Test:      g.Name(),
+		Location:  debug.CallerSimple(2),
+		Assertion: "T.Error",
+		Verbosity: Settings(g).Verbosity,
+		Time:      time.Now(),
+	}
+	r.Short, r.Long, r.Details, r.Meta = should.ParseFailure(msg)
+	g.add(r)
+}
+
+// close sends the group's combined result to the reporters, once.
+func (g *group) close() {
+	g.mu.Lock()
+	if g.closed {
+		g.mu.Unlock()
+		return
+	}
+	g.closed = true
+	results := g.results
+	g.mu.Unlock()
+	deliver(g.parent, g.combine(results))
+}
+
+// combine summarizes the group's results as one Result.
+func (g *group) combine(results []*Result) *Result {
+	cfg := Settings(g)
+	r := &Result{
+		Test:      g.Name(),
+		Location:  g.location,
+		Calls:     g.calls,
+		Assertion: "gotest.Group",
+		Passed:    true,
+		Verbosity: cfg.Verbosity,
+		Time:      time.Now(),
+	}
+	var (
+		failed            []*Result
+		actuals           []interface{}
+		shorts, longs     []string
+		details, metas    []string
+		firstWithLongText = map[string]int{}
+	)
+	for _, res := range results {
+		if !res.Passed {
+			failed = append(failed, res)
+		}
+	}
+	if len(failed) == 0 {
+		r.Short = fmt.Sprintf("All %d assertions in group passed.", len(results))
+		return r
+	}
+
+	for i, res := range failed {
+		n := i + 1
+		shorts = append(shorts, fmt.Sprintf("  %d. %s", n, res.Short))
+		long := fmt.Sprintf("%d. %s at %s: %s", n, res.Assertion, res.Location, res.Short)
+		if res.Long != "" {
+			if first, seen := firstWithLongText[res.Long]; seen {
+				long += fmt.Sprintf("\n   (explanation same as #%d)", first)
+			} else {
+				firstWithLongText[res.Long] = n
+				long += "\n   " + strings.Replace(res.Long, "\n", "\n   ", -1)
+			}
+		}
+		longs = append(longs, long)
+		if res.Details != "" {
+			details = append(details, fmt.Sprintf("%d. %s", n, res.Details))
+		}
+		if res.Meta != "" {
+			metas = append(metas, fmt.Sprintf("%d. %s", n, res.Meta))
+		}
+		if !containsValue(actuals, res.Actual) {
+			actuals = append(actuals, res.Actual)
+		}
+	}
+
+	r.Passed = false
+	r.FailNow = cfg.FailFast
+	r.Rerun = failed[0].Rerun
+	r.Short = fmt.Sprintf("%d of %d assertions failed in group:\n%s",
+		len(failed), len(results), strings.Join(shorts, "\n"))
+	r.Long = strings.Join(longs, "\n")
+	r.Details = strings.Join(details, "\n")
+	r.Meta = strings.Join(metas, "\n")
+	if len(actuals) == 1 {
+		r.Actual = actuals[0]
+	} else {
+		r.Actual = actuals
+	}
+	return r
+}
+
+// containsValue returns true if values has an item deeply equal to v.
+func containsValue(values []interface{}, v interface{}) bool {
+	for _, x := range values {
+		if reflect.DeepEqual(x, v) {
+			return true
+		}
+	}
+	return false
+}
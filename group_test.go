@@ -0,0 +1,90 @@
+package gotest
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/kindrid/gotest/should"
+)
+
+func TestGroup(t *testing.T) {
+	// Happy path
+	Group(t, func(g T) {
+		Assert(g, "forced", should.AlwaysPass)
+		Deny(g, "forced", should.AlwaysFail)
+	})
+
+	// Failures are reported together, once.
+	ft := &fakeT{name: "TestFake"}
+	Group(ft, func(g T) {
+		Assert(g, `{"id": 1}`, should.HaveFields, "name", reflect.String)
+		Assert(g, `{"id": 1}`, should.AlwaysPass)
+		Assert(g, `{"id": 1}`, should.HaveFields, "type", reflect.String)
+		Assert(t, ft.failed, should.BeFalse)
+	})
+	Assert(t, ft.failed, should.BeTrue)
+	Assert(t, len(ft.errors), should.Equal, 1)
+	Assert(t, ft.errors[0], should.ContainSubstring, "2 of 3 assertions failed in group")
+	Assert(t, ft.errors[0], should.ContainSubstring, "1. Field 'name' is missing.")
+	Assert(t, ft.errors[0], should.ContainSubstring, "2. Field 'type' is missing.")
+
+	// Reporters get one combined result.
+	rec := &recorder{}
+	withReporters(func() {
+		Group(&fakeT{name: "TestFake"}, func(g T) {
+			Assert(g, "forced", should.AlwaysFail)
+			Assert(g, "forced", should.AlwaysFail)
+		})
+	}, rec)
+	Require(t, len(rec.results), should.Equal, 1)
+	Assert(t, rec.results[0].Assertion, should.Equal, "gotest.Group")
+
+	// Assertions may be made from several goroutines.
+	ft = &fakeT{name: "TestFake"}
+	Group(ft, func(g T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				g.Error("forced")
+			}()
+		}
+		wg.Wait()
+	})
+	Assert(t, ft.errors[0], should.ContainSubstring, "10 of 10 assertions failed in group")
+
+	// At the Actuals level, the shared actual value is dumped once.
+	ft = &fakeT{name: "TestFakeActuals"}
+	With(ft, Overrides{Verbosity: Int(Actuals)})
+	defer ft.finish()
+	Group(ft, func(g T) {
+		Assert(g, `{"id": 1}`, should.HaveFields, "name", reflect.String)
+		Assert(g, `{"id": 1}`, should.HaveFields, "type", reflect.String)
+	})
+	Require(t, len(ft.errors), should.Equal, 1)
+	Assert(t, strings.Count(ft.errors[0], "LEFT-SIDE VALUE"), should.Equal, 1)
+
+	// Overrides attached to a group's T stay within the group.
+	ft = &fakeT{name: "TestFakeScoped"}
+	Group(ft, func(g T) {
+		With(g, Overrides{Verbosity: Int(Silent)})
+		Assert(t, Settings(g).Verbosity, should.Equal, Silent)
+		Group(g, func(inner T) {
+			Assert(t, Settings(inner).Verbosity, should.Equal, Silent)
+		})
+	})
+	Assert(t, Settings(ft), should.Resemble, Defaults())
+
+	// FailFast waits for the end of the group.
+	FailFast = true
+	defer func() { FailFast = false }()
+	ft = &fakeT{name: "TestFake"}
+	Group(ft, func(g T) {
+		Assert(g, "forced", should.AlwaysFail)
+		Assert(t, ft.stopped, should.BeFalse)
+	})
+	Assert(t, ft.stopped, should.BeTrue)
+}
@@ -0,0 +1,135 @@
+package gotest
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// JUnitReporter collects every assertion result during a package run so they
+// can be written as a JUnit XML report. Each test or subtest that makes
+// assertions becomes one testcase; each failed assertion becomes one failure
+// element. Tests that never call Assert or Deny don't appear.
+type JUnitReporter struct {
+	mu    sync.Mutex
+	cases map[string]*junitCase
+	order []string
+}
+
+// NewJUnitReporter creates an empty JUnitReporter.
+func NewJUnitReporter() *JUnitReporter {
+	return &JUnitReporter{cases: make(map[string]*junitCase)}
+}
+
+type junitSuite struct {
+	XMLName  xml.Name     `xml:"testsuite"`
+	Name     string       `xml:"name,attr"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Time     string       `xml:"time,attr"`
+	Cases    []*junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	ClassName  string          `xml:"classname,attr"`
+	Name       string          `xml:"name,attr"`
+	Assertions int             `xml:"assertions,attr"`
+	Time       string          `xml:"time,attr"`
+	Failures   []*junitFailure `xml:"failure"`
+
+	start, end time.Time
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// Report implements Reporter.
+func (jr *JUnitReporter) Report(t T, r *Result) {
+	jr.mu.Lock()
+	defer jr.mu.Unlock()
+	if jr.cases == nil {
+		jr.cases = make(map[string]*junitCase)
+	}
+	c, ok := jr.cases[r.Test]
+	if !ok {
+		c = &junitCase{
+			ClassName: strings.SplitN(r.Test, "/", 2)[0],
+			Name:      r.Test,
+			start:     r.Time,
+		}
+		jr.cases[r.Test] = c
+		jr.order = append(jr.order, r.Test)
+	}
+	c.Assertions++
+	c.end = r.Time
+	if r.Passed {
+		return
+	}
+	body := fmt.Sprintf("%s\n\n%s\n\nLocation: %s\nCalls: %s", r.Short, r.Long, r.Location, r.Calls)
+	if r.Details != "" {
+		body += "\n\nDETAILS: " + r.Details
+	}
+	c.Failures = append(c.Failures, &junitFailure{
+		Message: r.Short,
+		Type:    r.Assertion,
+		Body:    body,
+	})
+}
+
+// WriteXML writes the collected results as a JUnit XML testsuite. Testcase
+// times span the first through last assertion, so they are approximate.
+func (jr *JUnitReporter) WriteXML(w io.Writer) error {
+	jr.mu.Lock()
+	defer jr.mu.Unlock()
+	suite := junitSuite{Name: suiteName()}
+	var total time.Duration
+	for _, name := range jr.order {
+		c := jr.cases[name]
+		elapsed := c.end.Sub(c.start)
+		total += elapsed
+		c.Time = fmt.Sprintf("%.3f", elapsed.Seconds())
+		suite.Tests++
+		if len(c.Failures) > 0 {
+			suite.Failures++
+		}
+		suite.Cases = append(suite.Cases, c)
+	}
+	suite.Time = fmt.Sprintf("%.3f", total.Seconds())
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(suite); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// WriteFile writes the JUnit XML report to path.
+func (jr *JUnitReporter) WriteFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err = jr.WriteXML(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// suiteName guesses the package under test from the test binary's name.
+func suiteName() string {
+	return strings.TrimSuffix(filepath.Base(os.Args[0]), ".test")
+}
@@ -0,0 +1,35 @@
+package gotest
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+
+	"github.com/kindrid/gotest/should"
+)
+
+func TestJUnitReporter(t *testing.T) {
+	junit := NewJUnitReporter()
+	withReporters(func() {
+		t.Run("passing", func(t *testing.T) {
+			Assert(t, "forced", should.AlwaysPass)
+		})
+		tFake := &testing.T{}
+		Assert(tFake, "forced", should.AlwaysFail)
+		Assert(tFake, "forced", should.AlwaysFail)
+	}, junit)
+
+	buf := &bytes.Buffer{}
+	Assert(t, junit.WriteXML(buf), should.BeNil)
+	suite := junitSuite{}
+	Assert(t, xml.Unmarshal(buf.Bytes(), &suite), should.BeNil)
+	Assert(t, suite.Tests, should.Equal, 2)
+	Assert(t, suite.Failures, should.Equal, 1)
+	Assert(t, suite.Cases[0].Name, should.Equal, "TestJUnitReporter/passing")
+	Assert(t, suite.Cases[0].ClassName, should.Equal, "TestJUnitReporter")
+	Assert(t, len(suite.Cases[0].Failures), should.Equal, 0)
+	Assert(t, suite.Cases[1].Assertions, should.Equal, 2)
+	Assert(t, len(suite.Cases[1].Failures), should.Equal, 2)
+	Assert(t, suite.Cases[1].Failures[0].Type, should.Equal, "should.AlwaysFail")
+	Assert(t, suite.Cases[1].Failures[0].Body, should.ContainSubstring, "Location: junit_test.go:")
+}
@@ -0,0 +1,81 @@
+package gotest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sync"
+
+	"github.com/kindrid/gotest/debug"
+)
+
+// Pending describes one piece of work noted with Later.
+type Pending struct {
+	Test        string `json:"test"`
+	Location    string `json:"location"`
+	Description string `json:"description"`
+}
+
+var (
+	pendingMu    sync.Mutex
+	pendingItems []Pending
+)
+
+// skipper is the part of *testing.T used to skip pending tests.
+type skipper interface {
+	Skip(args ...interface{})
+}
+
+// Later describes pending tests. Each call is remembered for the summary that
+// Main prints at the end of the run. With SkipPending, the test is skipped
+// from here on; with StrictPending, the test fails so pending work can't pile
+// up unnoticed.
+func Later(t T, desc string, ignored ...interface{}) {
+	item := Pending{Test: t.Name(), Location: debug.CallerSimple(1), Description: desc}
+	pendingMu.Lock()
+	pendingItems = append(pendingItems, item)
+	pendingMu.Unlock()
+
+	switch {
+	case StrictPending:
+		t.Errorf("LATER: %s (%s) fails because of --gotest-strict-pending", desc, item.Location)
+	case SkipPending:
+		if s, ok := t.(skipper); ok {
+			s.Skip(fmt.Sprintf("LATER: %s", desc))
+			return
+		}
+		t.Logf("LATER: %s", desc)
+	default:
+		t.Logf("LATER: %s", desc)
+	}
+}
+
+// PendingItems returns everything noted with Later so far.
+func PendingItems() []Pending {
+	pendingMu.Lock()
+	defer pendingMu.Unlock()
+	return append([]Pending{}, pendingItems...)
+}
+
+// WritePendingSummary lists the pending items in a human-readable form. It
+// writes nothing if there are none.
+func WritePendingSummary(w io.Writer) {
+	items := PendingItems()
+	if len(items) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "PENDING: %d item(s) noted with gotest.Later\n", len(items))
+	for _, item := range items {
+		fmt.Fprintf(w, "  %s (%s): %s\n", item.Test, item.Location, item.Description)
+	}
+}
+
+// WritePendingJSON writes the pending items as a JSON array to path.
+func WritePendingJSON(path string) error {
+	data, err := json.MarshalIndent(PendingItems(), "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(data, '\n'), 0644)
+}
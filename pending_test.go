@@ -0,0 +1,61 @@
+package gotest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/kindrid/gotest/should"
+)
+
+func TestLater(t *testing.T) {
+	pendingMu.Lock()
+	saved := pendingItems
+	pendingItems = nil
+	pendingMu.Unlock()
+	defer func() {
+		pendingMu.Lock()
+		pendingItems = saved
+		pendingMu.Unlock()
+	}()
+
+	ft := &fakeT{name: "TestFake"}
+	Later(ft, "check the edge cases")
+	Assert(t, ft.failed, should.BeFalse)
+	Assert(t, ft.logs, should.Resemble, []string{"LATER: check the edge cases"})
+
+	StrictPending = true
+	Later(ft, "check the corner cases")
+	StrictPending = false
+	Assert(t, ft.failed, should.BeTrue)
+
+	t.Run("skipped", func(t *testing.T) {
+		SkipPending = true
+		defer func() { SkipPending = false }()
+		Later(t, "write this test")
+		t.Error("Later should have skipped this test")
+	})
+
+	items := PendingItems()
+	Require(t, len(items), should.Equal, 3)
+	Assert(t, items[0], should.Resemble, Pending{Test: "TestFake", Location: items[0].Location, Description: "check the edge cases"})
+	Assert(t, items[0].Location, should.StartWith, "pending_test.go:")
+	Assert(t, items[2].Test, should.Equal, "TestLater/skipped")
+
+	buf := &bytes.Buffer{}
+	WritePendingSummary(buf)
+	Assert(t, buf.String(), should.StartWith, "PENDING: 3 item(s)")
+	Assert(t, buf.String(), should.ContainSubstring, "TestFake (pending_test.go:")
+
+	f, err := ioutil.TempFile("", "pending")
+	Require(t, err, should.BeNil)
+	f.Close()
+	defer os.Remove(f.Name())
+	Require(t, WritePendingJSON(f.Name()), should.BeNil)
+	data, _ := ioutil.ReadFile(f.Name())
+	var decoded []Pending
+	Assert(t, json.Unmarshal(data, &decoded), should.BeNil)
+	Assert(t, decoded, should.Resemble, items)
+}
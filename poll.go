@@ -0,0 +1,114 @@
+package gotest
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kindrid/gotest/should"
+)
+
+const (
+	// DefaultWithin is how long Eventually and Consistently poll unless told
+	// otherwise with Within.
+	DefaultWithin = time.Second
+
+	// DefaultEvery is the pause between polls unless set with Every.
+	DefaultEvery = 50 * time.Millisecond
+)
+
+// PollOption tunes Eventually and Consistently. Pass options among the
+// expected values; they are removed before the assertion sees them.
+type PollOption func(*polling)
+
+// Within sets how long to keep polling.
+func Within(d time.Duration) PollOption {
+	return func(p *polling) { p.within = d }
+}
+
+// Every sets the pause between polls.
+func Every(d time.Duration) PollOption {
+	return func(p *polling) { p.every = d }
+}
+
+// polling holds the settings and progress of a polling assertion.
+type polling struct {
+	within, every time.Duration
+	attempts      int
+	elapsed       time.Duration
+	last          interface{}
+}
+
+// newPolling separates PollOptions from an assertion's expected values.
+func newPolling(args []interface{}) (p *polling, expected []interface{}) {
+	p = &polling{within: DefaultWithin, every: DefaultEvery}
+	for _, arg := range args {
+		if opt, ok := arg.(PollOption); ok {
+			opt(p)
+			continue
+		}
+		expected = append(expected, arg)
+	}
+	return
+}
+
+// run calls actual and assertion until done says to stop, time runs out, or
+// the run is aborted (see Context). It returns the last failure message.
+func (p *polling) run(actual func() interface{}, assertion should.Assertion, expected []interface{}, done func(fail string) bool) (fail string) {
+	start := time.Now()
+	deadline := start.Add(p.within)
+	for {
+		p.attempts++
+		p.last = actual()
+		fail = assertion(p.last, expected...)
+		p.elapsed = time.Since(start)
+		if done(fail) || !time.Now().Add(p.every).Before(deadline) {
+			return
+		}
+		pause := time.NewTimer(p.every)
+		select {
+		case <-pause.C:
+		case <-Context().Done():
+			pause.Stop()
+			return
+		}
+	}
+}
+
+// Eventually polls actual and checks it with assertion until the assertion
+// passes or time runs out. On failure it reports the last failure message
+// and how many attempts were made.
+//
+//	gotest.Eventually(t, getJobStatus, should.Equal, "done",
+//		gotest.Within(5*time.Second), gotest.Every(100*time.Millisecond))
+func Eventually(t T, actual func() interface{}, assertion should.Assertion, expected ...interface{}) {
+	p, expected := newPolling(expected)
+	fail := p.run(actual, assertion, expected, func(fail string) bool { return fail == "" })
+	if fail != "" {
+		short, long, details, meta := should.ParseFailure(fail)
+		fail = should.FormatFailure(
+			fmt.Sprintf("Still failing after %d attempts over %s: %s", p.attempts, p.elapsed, short),
+			long, details, meta)
+	}
+	r := newResult(t, 1, should.AssertionName(assertion), fail, p.last, expected)
+	r.Duration = p.elapsed
+	conclude(t, r)
+}
+
+// Consistently polls actual and checks it with assertion for the whole time
+// window, failing as soon as the assertion does.
+//
+//	gotest.Consistently(t, getBalance, should.BeGreaterThanOrEqualTo, 0,
+//		gotest.Within(time.Second))
+func Consistently(t T, actual func() interface{}, assertion should.Assertion, expected ...interface{}) {
+	p, expected := newPolling(expected)
+	fail := p.run(actual, assertion, expected, func(fail string) bool { return fail != "" })
+	if fail != "" {
+		short, long, details, meta := should.ParseFailure(fail)
+		fail = should.FormatFailure(
+			fmt.Sprintf("Stopped passing on attempt %d after %s: %s", p.attempts, p.elapsed, short),
+			long, details, meta)
+	}
+	r := newResult(t, 1, should.AssertionName(assertion), fail, p.last, expected)
+	r.Duration = p.elapsed
+	conclude(t, r)
+}
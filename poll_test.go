@@ -0,0 +1,48 @@
+package gotest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kindrid/gotest/should"
+)
+
+func TestEventually(t *testing.T) {
+	count := 0
+	counter := func() interface{} {
+		count++
+		return count
+	}
+	Eventually(t, counter, should.BeGreaterThanOrEqualTo, 3, Every(time.Millisecond))
+	Assert(t, count, should.Equal, 3)
+
+	rec := &recorder{}
+	withReporters(func() {
+		Eventually(&fakeT{}, counter, should.BeLessThan, 0, Within(20*time.Millisecond), Every(5*time.Millisecond))
+	}, rec)
+	Require(t, len(rec.results), should.Equal, 1)
+	r := rec.results[0]
+	Assert(t, r.Passed, should.BeFalse)
+	Assert(t, r.Assertion, should.Equal, "assertions.ShouldBeLessThan")
+	Assert(t, r.Short, should.StartWith, "Still failing after ")
+	Assert(t, r.Expected, should.Resemble, []interface{}{0})
+	Assert(t, r.Actual, should.Equal, count)
+}
+
+func TestConsistently(t *testing.T) {
+	count := 0
+	counter := func() interface{} {
+		count++
+		return count
+	}
+	Consistently(t, counter, should.BeGreaterThan, 0, Within(20*time.Millisecond), Every(5*time.Millisecond))
+	Assert(t, count, should.BeGreaterThan, 1)
+
+	rec := &recorder{}
+	count = 0
+	withReporters(func() {
+		Consistently(&fakeT{}, counter, should.BeLessThan, 3, Within(time.Second), Every(time.Millisecond))
+	}, rec)
+	Require(t, len(rec.results), should.Equal, 1)
+	Assert(t, rec.results[0].Short, should.StartWith, "Stopped passing on attempt 3 ")
+}
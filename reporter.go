@@ -0,0 +1,247 @@
+package gotest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// Result describes the outcome of one assertion made through Assert, Deny,
+// Require, or RequireNot. Every Result is handed to each active Reporter.
+type Result struct {
+	Test      string        `json:"test"`               // t.Name() of the asserting test
+	Location  string        `json:"location"`           // file.go:line of the assertion
+	Calls     string        `json:"calls,omitempty"`    // short call stack leading to the assertion
+	Stack     string        `json:"stack,omitempty"`    // full call stack, only if StackDepth > 0
+	Assertion string        `json:"assertion"`          // assertion function name, e.g. should.HaveFields
+	Negated   bool          `json:"negated,omitempty"`  // true for Deny
+	Passed    bool          `json:"passed"`             // whether the (possibly negated) assertion held
+	Short     string        `json:"short,omitempty"`    // see should.ParseFailure
+	Long      string        `json:"long,omitempty"`     // see should.ParseFailure
+	Details   string        `json:"details,omitempty"`  // see should.ParseFailure
+	Meta      string        `json:"meta,omitempty"`     // see should.ParseFailure
+	Path      string        `json:"path,omitempty"`     // where in the actual value it failed, see should.Failure
+	Diff      string        `json:"diff,omitempty"`     // unified diff of expected to actual, at Long verbosity
+	Rerun     string        `json:"rerun,omitempty"`    // go test command re-running just this test, for failures
+	Actual    interface{}   `json:"-"`                  // left-side value
+	Expected  []interface{} `json:"-"`                  // right-side values
+	Verbosity int           `json:"verbosity"`          // verbosity level in effect
+	Required  bool          `json:"required,omitempty"` // true for Require and RequireNot
+	FailNow   bool          `json:"failNow,omitempty"`  // the test stops after this result
+	Time      time.Time     `json:"time"`               // when the assertion ran
+	Duration  time.Duration `json:"nanoseconds"`        // how long the assertion took
+}
+
+// Reporter receives the Result of every assertion. Reporters only render
+// results; Assert itself marks the test as failed, so removing the default
+// TextReporter silences the test log without hiding failures. Reporters that
+// hold files open should also implement io.Closer; Run closes them after the
+// tests.
+type Reporter interface {
+	Report(t T, r *Result)
+}
+
+var (
+	reportersMu sync.RWMutex
+	reporters   = []Reporter{TextReporter{}}
+
+	flagReporterMu sync.Mutex
+	flagReporter   *JSONLinesReporter
+)
+
+// AddReporter appends a Reporter to those receiving assertion results.
+func AddReporter(r Reporter) {
+	reportersMu.Lock()
+	defer reportersMu.Unlock()
+	reporters = append(reporters, r)
+}
+
+// SetReporters replaces all active reporters. SetReporters() with no arguments
+// leaves only the reporter chosen by the -gotest-report-json flag, if any.
+func SetReporters(rs ...Reporter) {
+	reportersMu.Lock()
+	defer reportersMu.Unlock()
+	reporters = append([]Reporter{}, rs...)
+}
+
+// activeReporters lists the registered reporters plus the one requested by
+// flag.
+func activeReporters() (result []Reporter) {
+	reportersMu.RLock()
+	result = append(result, reporters...)
+	reportersMu.RUnlock()
+
+	flagReporterMu.Lock()
+	defer flagReporterMu.Unlock()
+	if ReportJSON == "" {
+		return
+	}
+	if flagReporter == nil || flagReporter.Path != ReportJSON {
+		flagReporter = &JSONLinesReporter{Path: ReportJSON}
+	}
+	return append(result, flagReporter)
+}
+
+// closeReporters closes the active reporters that implement io.Closer,
+// returning the first error.
+func closeReporters() (err error) {
+	for _, rep := range activeReporters() {
+		if c, ok := rep.(io.Closer); ok {
+			if cerr := c.Close(); cerr != nil && err == nil {
+				err = cerr
+			}
+		}
+	}
+	return
+}
+
+// report hands a result to every active reporter.
+func report(t T, r *Result) {
+	for _, rep := range activeReporters() {
+		rep.Report(t, r)
+	}
+}
+
+// TextReporter writes results to the test log, as gotest always has. Failures
+// go through t.Error; successes are logged only at the Debug verbosity level.
+type TextReporter struct{}
+
+// Report implements Reporter.
+func (TextReporter) Report(t T, r *Result) {
+	vocal := func(minLevel int) bool { return r.Verbosity >= minLevel }
+	if r.Passed && !vocal(Debug) {
+		return
+	}
+	status := "FAILED"
+	if r.Passed {
+		status = "PASSED"
+	}
+
+	cfg := Settings(t)
+	msg := ""
+	if r.Stack != "" {
+		msg += fmt.Sprintf("\nTest Failure Stack Trace: %s\n\n", r.Stack)
+	}
+	if vocal(Short) {
+		msg += fmt.Sprintf("%s %s: %s", status, r.Test, r.Short)
+		if r.Path != "" {
+			msg += fmt.Sprintf("\nAT: %s", r.Path)
+		}
+		if r.Rerun != "" {
+			msg += fmt.Sprintf("\nRERUN: %s", r.Rerun)
+		}
+	}
+	if vocal(Long) {
+		msg += fmt.Sprintf("\nEXTRA INFO: %s\n", r.Long+"\nCalls:"+r.Calls)
+	}
+	if r.Diff != "" && vocal(Long) {
+		diff := r.Diff
+		if useColor() {
+			diff = colorDiff(diff)
+		}
+		msg += fmt.Sprintf("\nDIFF:\n%s", diff)
+	}
+	if vocal(Actuals) {
+		msg += cfg.inspect("\nLEFT-SIDE VALUE (usually actual value-under-test)", r.Actual)
+	}
+	if vocal(Expecteds) {
+		msg += cfg.inspect("\nRIGHT-SIDE VALUES", r.Expected)
+	}
+	if r.Details != "" && vocal(Debug) {
+		msg += fmt.Sprintf("\nDETAILS: %s\n", r.Details)
+	}
+	if r.Meta != "" && vocal(Insane) {
+		msg += fmt.Sprintf("\nINTERNALS (FOR DEBUGGING ASSERTIONS): %s\n", r.Meta)
+	}
+
+	if r.Passed {
+		t.Logf("%s", msg)
+		return
+	}
+	if r.Required {
+		msg += "\nNOTE: skipping remaining assertions for this test because a required assertion failed."
+	} else if r.FailNow {
+		msg += "\nNOTE: skipping remaining assertions for this test because of --gotest-failfast."
+	} else {
+		msg += "\n"
+	}
+	t.Error(msg)
+}
+
+// JSONLinesReporter writes each Result as one line of JSON. Set Writer, or set
+// Path and the file will be opened for appending on the first result and
+// closed by Close. The -gotest-report-json flag installs one of these
+// automatically.
+type JSONLinesReporter struct {
+	Path   string
+	Writer io.Writer
+
+	mu     sync.Mutex
+	file   *os.File // opened from Path
+	failed bool
+}
+
+// jsonResult adds the actual and expected values to a Result's JSON.
+type jsonResult struct {
+	*Result
+	Actual   json.RawMessage   `json:"actual"`
+	Expected []json.RawMessage `json:"expected"`
+}
+
+// Report implements Reporter.
+func (jr *JSONLinesReporter) Report(t T, r *Result) {
+	line := jsonResult{Result: r, Actual: jsonValue(r.Actual)}
+	for _, e := range r.Expected {
+		line.Expected = append(line.Expected, jsonValue(e))
+	}
+	data, err := json.Marshal(line)
+	if err != nil {
+		t.Logf("gotest: can't encode assertion result as JSON: %s", err)
+		return
+	}
+
+	jr.mu.Lock()
+	defer jr.mu.Unlock()
+	if jr.failed {
+		return
+	}
+	if jr.Writer == nil {
+		f, err := os.OpenFile(jr.Path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+		if err != nil {
+			jr.failed = true
+			t.Logf("gotest: can't open JSON report %s: %s", jr.Path, err)
+			return
+		}
+		jr.file, jr.Writer = f, f
+	}
+	if _, err = jr.Writer.Write(append(data, '\n')); err != nil {
+		jr.failed = true
+		t.Logf("gotest: can't write JSON report %s: %s", jr.Path, err)
+	}
+}
+
+// Close implements io.Closer, closing the file opened from Path, if any. A
+// later result opens it again. A Writer that was set is left alone.
+func (jr *JSONLinesReporter) Close() error {
+	jr.mu.Lock()
+	defer jr.mu.Unlock()
+	if jr.file == nil {
+		return nil
+	}
+	err := jr.file.Close()
+	jr.file, jr.Writer = nil, nil
+	return err
+}
+
+// jsonValue encodes values that JSON can represent and falls back to Go
+// syntax for the rest (funcs, channels, cyclic structures...)
+func jsonValue(v interface{}) json.RawMessage {
+	data, err := json.Marshal(v)
+	if err != nil {
+		data, _ = json.Marshal(fmt.Sprintf("%#v", v))
+	}
+	return data
+}
@@ -0,0 +1,85 @@
+package gotest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kindrid/gotest/should"
+)
+
+// recorder is a Reporter that keeps every result it's given.
+type recorder struct {
+	results []*Result
+}
+
+func (rec *recorder) Report(t T, r *Result) {
+	rec.results = append(rec.results, r)
+}
+
+// withReporters runs fn with only rs active, restoring the defaults afterwards.
+func withReporters(fn func(), rs ...Reporter) {
+	reportersMu.RLock()
+	saved := append([]Reporter{}, reporters...)
+	reportersMu.RUnlock()
+	defer SetReporters(saved...)
+	SetReporters(rs...)
+	fn()
+}
+
+func TestReporters(t *testing.T) {
+	rec := &recorder{}
+	buf := &bytes.Buffer{}
+	tFake := &testing.T{}
+	withReporters(func() {
+		Assert(tFake, "forced", should.AlwaysFail, 1, 2)
+		Deny(tFake, "forced", should.AlwaysFail)
+	}, rec, &JSONLinesReporter{Writer: buf})
+
+	Assert(t, tFake.Failed(), should.BeTrue)
+	Assert(t, len(rec.results), should.Equal, 2)
+	failed := rec.results[0]
+	Assert(t, failed.Passed, should.BeFalse)
+	Assert(t, failed.Assertion, should.Equal, "should.AlwaysFail")
+	Assert(t, failed.Location, should.StartWith, "reporter_test.go:")
+	Assert(t, failed.Short, should.StartWith, "Forced Fail")
+	Assert(t, failed.Expected, should.Resemble, []interface{}{1, 2})
+	Assert(t, rec.results[1].Passed, should.BeTrue)
+	Assert(t, rec.results[1].Negated, should.BeTrue)
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	Assert(t, len(lines), should.Equal, 2)
+	decoded := map[string]interface{}{}
+	Assert(t, json.Unmarshal(lines[0], &decoded), should.BeNil)
+	Assert(t, decoded["assertion"], should.Equal, "should.AlwaysFail")
+	Assert(t, decoded["actual"], should.Equal, "forced")
+	Assert(t, decoded["passed"], should.Equal, false)
+}
+
+func TestJSONLinesFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "report")
+	Require(t, err, should.BeNil)
+	defer os.RemoveAll(dir)
+	jr := &JSONLinesReporter{Path: filepath.Join(dir, "results.jsonl")}
+	ft := &fakeT{name: "TestJSONLinesFile"}
+
+	withReporters(func() { Assert(ft, 1, should.Equal, 1) }, jr)
+	Require(t, jr.Close(), should.BeNil)
+	Assert(t, jr.Writer, should.BeNil)
+	data, err := ioutil.ReadFile(jr.Path)
+	Require(t, err, should.BeNil)
+	Assert(t, bytes.Count(data, []byte("\n")), should.Equal, 1)
+
+	// reporting after Close appends to the file again
+	withReporters(func() {
+		Assert(ft, 2, should.Equal, 2)
+		err = closeReporters()
+	}, jr)
+	Require(t, err, should.BeNil)
+	data, _ = ioutil.ReadFile(jr.Path)
+	Assert(t, bytes.Count(data, []byte("\n")), should.Equal, 2)
+	Assert(t, jr.Close(), should.BeNil)
+}
@@ -0,0 +1,80 @@
+package gotest
+
+import (
+	"flag"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// registeredFlags holds the names of the flags added by RegisterFlags.
+var registeredFlags = map[string]bool{}
+
+// shellSafe matches arguments that need no quoting in a POSIX shell.
+var shellSafe = regexp.MustCompile(`^[A-Za-z0-9_./:=,+@%-]+$`)
+
+// RerunCommand returns a go test command that runs just the test (or
+// subtest) named test in the package with import path pkg, passing along the
+// gotest flags that were set on the command line for this run (settings from
+// the environment or ConfigFileName apply to the re-run by themselves):
+//
+//	go test -run '^TestUsers$/^get_one$' github.com/example/users -args -gotest-verbosity=2
+func RerunCommand(pkg, test string) string {
+	args := []string{"go", "test", "-run", shellQuote(RunPattern(test))}
+	if pkg != "" {
+		args = append(args, shellQuote(pkg))
+	}
+	var flags []string
+	flag.Visit(func(f *flag.Flag) {
+		if preset, ok := presetFlags[f.Name]; ok && preset == f.Value.String() {
+			return
+		}
+		if registeredFlags[f.Name] {
+			flags = append(flags, shellQuote(fmt.Sprintf("-%s=%s", f.Name, f.Value)))
+		}
+	})
+	if len(flags) > 0 {
+		args = append(append(args, "-args"), flags...)
+	}
+	return strings.Join(args, " ")
+}
+
+// RunPattern returns a -run regular expression matching exactly the test or
+// subtest named test, e.g. "^TestUsers$/^get_one$". Each level of the name is
+// rewritten the way the testing package rewrites subtest names (spaces become
+// underscores, unprintable characters are escaped) and then quoted, so names
+// from t.Name() and the names given to t.Run both work.
+func RunPattern(test string) string {
+	segments := strings.Split(test, "/")
+	for i, segment := range segments {
+		segments[i] = "^" + regexp.QuoteMeta(rewriteTestName(segment)) + "$"
+	}
+	return strings.Join(segments, "/")
+}
+
+// rewriteTestName mimics how the testing package rewrites subtest names.
+func rewriteTestName(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		switch {
+		case unicode.IsSpace(r):
+			b.WriteByte('_')
+		case !strconv.IsPrint(r):
+			quoted := strconv.QuoteRune(r)
+			b.WriteString(quoted[1 : len(quoted)-1])
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// shellQuote quotes arg for a POSIX shell if it needs it.
+func shellQuote(arg string) string {
+	if shellSafe.MatchString(arg) {
+		return arg
+	}
+	return "'" + strings.Replace(arg, "'", `'\''`, -1) + "'"
+}
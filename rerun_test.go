@@ -0,0 +1,61 @@
+package gotest
+
+import (
+	"flag"
+	"os"
+	"testing"
+
+	"github.com/kindrid/gotest/should"
+)
+
+func TestRunPattern(t *testing.T) {
+	Assert(t, RunPattern("TestUsers"), should.Equal, "^TestUsers$")
+	Assert(t, RunPattern("TestUsers/get one (admin)/#01"), should.Equal, `^TestUsers$/^get_one_\(admin\)$/^#01$`)
+	Assert(t, RunPattern("TestX/a\tb\x00c.d"), should.Equal, `^TestX$/^a_b\\x00c\.d$`)
+
+	t.Run("with spaces & stars*", func(t *testing.T) {
+		// names from t.Name() are already rewritten and come back the same
+		Assert(t, RunPattern(t.Name()), should.Equal, RunPattern("TestRunPattern/with spaces & stars*"))
+	})
+}
+
+func TestRerunCommand(t *testing.T) {
+	Assert(t, shellQuote("-gotest-verbosity=2"), should.Equal, "-gotest-verbosity=2")
+	Assert(t, shellQuote("it's"), should.Equal, `'it'\''s'`)
+	Assert(t, RerunCommand("example.com/pkg", "TestA/b c"), should.Equal, "go test -run '^TestA$/^b_c$' example.com/pkg")
+
+	// flags registered by RegisterFlags and set for this run are passed along
+	fs := flag.CommandLine
+	defer func() { flag.CommandLine = fs }()
+	flag.CommandLine = flag.NewFlagSet("test", flag.ContinueOnError)
+	var verbosity int
+	var other string
+	flag.IntVar(&verbosity, "gotest-verbosity", 0, "")
+	flag.StringVar(&other, "other", "", "")
+	registeredFlags["gotest-verbosity"] = true
+	defer delete(registeredFlags, "gotest-verbosity")
+	flag.CommandLine.Parse([]string{"-gotest-verbosity", "2", "-other", "x"})
+	Assert(t, RerunCommand("example.com/pkg", "TestA"), should.Equal,
+		"go test -run '^TestA$' example.com/pkg -args -gotest-verbosity=2")
+
+	// settings from the environment or settings file aren't repeated
+	os.Setenv("GOTEST_VERBOSITY", "3")
+	defer os.Unsetenv("GOTEST_VERBOSITY")
+	defer delete(presetFlags, "gotest-verbosity")
+	applySettingSources("gotest-", []string{"verbosity"})
+	Assert(t, RerunCommand("example.com/pkg", "TestA"), should.Equal, "go test -run '^TestA$' example.com/pkg")
+	// unless the command line changes them
+	flag.CommandLine.Parse([]string{"-gotest-verbosity", "4"})
+	Assert(t, RerunCommand("example.com/pkg", "TestA"), should.Equal,
+		"go test -run '^TestA$' example.com/pkg -args -gotest-verbosity=4")
+
+	rec := &recorder{}
+	ft := &fakeT{name: "TestA/b"}
+	withReporters(func() {
+		Assert(ft, "forced", should.AlwaysFail)
+		Assert(ft, "forced", should.AlwaysPass)
+	}, rec, TextReporter{})
+	Assert(t, ft.errors[0], should.ContainSubstring, "\nRERUN: go test -run")
+	Assert(t, rec.results[0].Rerun, should.StartWith, "go test -run '^TestA$/^b$' github.com/kindrid/gotest")
+	Assert(t, rec.results[1].Rerun, should.Equal, "")
+}
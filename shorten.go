@@ -0,0 +1,267 @@
+package gotest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/kindrid/gotest/should"
+)
+
+// shortener renders values for Inspectv within a Config's limits. A limit of
+// zero or less means no limit.
+type shortener struct {
+	bytes, depth, elements, str int
+}
+
+func (cfg Config) shortener() shortener {
+	return shortener{
+		bytes:    cfg.MaxInspectBytes,
+		depth:    cfg.MaxInspectDepth,
+		elements: cfg.MaxInspectElements,
+		str:      cfg.MaxInspectString,
+	}
+}
+
+// inspect renders x: JSON as (shortened) pretty JSON, anything else as a
+// (shortened) spew dump.
+func (s shortener) inspect(x interface{}) string {
+	var text string
+	if explorer, err := should.ParseJSON(x); err == nil && !decodedJSON(x) {
+		buf := &bytes.Buffer{}
+		s.writeJSON(buf, explorer.Data(), "", 0)
+		text = buf.String() + "\n"
+	} else {
+		text = s.dump(x)
+	}
+	if s.bytes > 0 && len(text) > s.bytes {
+		cut := cutString(text, s.bytes)
+		text = fmt.Sprintf("%s\n... %d more bytes ...\n", cut, len(text)-len(cut))
+	}
+	return text
+}
+
+// decodedJSON reports whether x is JSON already decoded into Go maps and
+// slices, which should.ParseJSON accepts but which are shown as the Go values
+// they are.
+func decodedJSON(x interface{}) bool {
+	switch x.(type) {
+	case map[string]interface{}, []interface{}:
+		return true
+	}
+	return false
+}
+
+// writeJSON pretty-prints decoded JSON like json.MarshalIndent, eliding what
+// exceeds the limits.
+func (s shortener) writeJSON(buf *bytes.Buffer, v interface{}, indent string, depth int) {
+	inner := indent + "  "
+	switch x := v.(type) {
+	case map[string]interface{}:
+		if len(x) == 0 {
+			buf.WriteString("{}")
+			return
+		}
+		if s.depth > 0 && depth >= s.depth {
+			fmt.Fprintf(buf, "{ ... %d keys ... }", len(x))
+			return
+		}
+		keys := make([]string, 0, len(x))
+		for k := range x {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		shown := s.shown(len(keys))
+		buf.WriteString("{\n")
+		for i, k := range keys[:shown] {
+			buf.WriteString(inner + jsonText(k) + ": ")
+			s.writeJSON(buf, x[k], inner, depth+1)
+			if i < len(keys)-1 {
+				buf.WriteString(",")
+			}
+			buf.WriteString("\n")
+		}
+		if shown < len(keys) {
+			fmt.Fprintf(buf, "%s... %d more keys ...\n", inner, len(keys)-shown)
+		}
+		buf.WriteString(indent + "}")
+	case []interface{}:
+		if len(x) == 0 {
+			buf.WriteString("[]")
+			return
+		}
+		if s.depth > 0 && depth >= s.depth {
+			fmt.Fprintf(buf, "[ ... %d elements ... ]", len(x))
+			return
+		}
+		shown := s.shown(len(x))
+		buf.WriteString("[\n")
+		for i, element := range x[:shown] {
+			buf.WriteString(inner)
+			s.writeJSON(buf, element, inner, depth+1)
+			if i < len(x)-1 {
+				buf.WriteString(",")
+			}
+			buf.WriteString("\n")
+		}
+		if shown < len(x) {
+			fmt.Fprintf(buf, "%s... %d more elements ...\n", inner, len(x)-shown)
+		}
+		buf.WriteString(indent + "]")
+	case string:
+		cut := s.cut(x)
+		buf.WriteString(jsonText(cut))
+		if len(cut) < len(x) {
+			fmt.Fprintf(buf, " ... %d more bytes ...", len(x)-len(cut))
+		}
+	default:
+		buf.WriteString(jsonText(x))
+	}
+}
+
+// shown is how many of n elements fit the element limit.
+func (s shortener) shown(n int) int {
+	if s.elements > 0 && n > s.elements {
+		return s.elements
+	}
+	return n
+}
+
+// cut shortens text to the string limit.
+func (s shortener) cut(text string) string {
+	if s.str > 0 && len(text) > s.str {
+		return cutString(text, s.str)
+	}
+	return text
+}
+
+// spewHeader matches the line spew writes to open a slice, array, or map.
+var spewHeader = regexp.MustCompile(`\(len=(\d+)(?: cap=\d+)?\) \{$`)
+
+// spewString matches the start of a string as spew writes it.
+var spewString = regexp.MustCompile(`\) \(len=\d+\) "`)
+
+// dump renders x with spew, eliding what exceeds the limits. spew itself
+// handles depth; elements and strings are shortened in its output.
+func (s shortener) dump(x interface{}) string {
+	config := spew.ConfigState{Indent: " ", SortKeys: true}
+	if s.depth > 0 {
+		config.MaxDepth = s.depth
+	}
+	text := config.Sdump(x)
+	if s.elements <= 0 && s.str <= 0 {
+		return text
+	}
+
+	var (
+		open []*spewContainer
+		out  []string
+	)
+	for _, line := range strings.Split(text, "\n") {
+		body := strings.TrimLeft(line, " ")
+		indent := len(line) - len(body)
+		if n := len(open); n > 0 {
+			top := open[n-1]
+			switch {
+			case indent == top.indent && strings.HasPrefix(body, "}"):
+				if s.elements > 0 && top.seen > s.elements {
+					out = append(out, strings.Repeat(" ", indent+1)+top.marker(s.elements))
+				}
+				open = open[:n-1]
+			case indent == top.indent+1 && !strings.HasPrefix(body, "}"):
+				top.seen++
+				fallthrough
+			default:
+				if s.elements > 0 && top.seen > s.elements {
+					continue
+				}
+			}
+		}
+		out = append(out, s.cutQuoted(line))
+		if m := spewHeader.FindStringSubmatch(body); m != nil {
+			length, _ := strconv.Atoi(m[1])
+			open = append(open, &spewContainer{indent: indent, length: length, hexdump: strings.Contains(body, "]uint8)")})
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+// spewContainer is a slice, array, or map in spew's output. It holds the
+// lines indented further than its opening line, up to the "}" line at the
+// same indent; each of its elements starts at indent+1. Byte slices are hex
+// dumps of 16 bytes per line.
+type spewContainer struct {
+	indent, length, seen int
+	hexdump              bool
+}
+
+// marker describes the elements left out after the first shown.
+func (c *spewContainer) marker(shown int) string {
+	if c.hexdump {
+		return fmt.Sprintf("... %d more bytes ...", c.length-16*shown)
+	}
+	return fmt.Sprintf("... %d more elements ...", c.length-shown)
+}
+
+// cutQuoted shortens the strings spew wrote on line.
+func (s shortener) cutQuoted(line string) string {
+	if s.str <= 0 {
+		return line
+	}
+	matches := spewString.FindAllStringIndex(line, -1)
+	for i := len(matches) - 1; i >= 0; i-- {
+		start := matches[i][1] - 1
+		end := closingQuote(line, start)
+		if end < 0 {
+			continue
+		}
+		text, err := strconv.Unquote(line[start : end+1])
+		if err != nil || len(text) <= s.str {
+			continue
+		}
+		cut := cutString(text, s.str)
+		line = line[:start] + fmt.Sprintf("%q ... %d more bytes ...", cut, len(text)-len(cut)) + line[end+1:]
+	}
+	return line
+}
+
+// closingQuote finds the quote ending the Go string literal opened at start.
+func closingQuote(text string, start int) int {
+	for i := start + 1; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+// cutString cuts text to at most max bytes without splitting a rune.
+func cutString(text string, max int) string {
+	if len(text) <= max {
+		return text
+	}
+	for max > 0 && !utf8.RuneStart(text[max]) {
+		max--
+	}
+	return text[:max]
+}
+
+// jsonText encodes v as compact JSON, leaving <, >, and & readable.
+func jsonText(v interface{}) string {
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return fmt.Sprintf("%#v", v)
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
@@ -0,0 +1,86 @@
+package gotest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kindrid/gotest/should"
+)
+
+type shortenSample struct {
+	Name  string
+	Items []int
+	Blob  []byte
+	Tags  map[string]int
+	Next  *shortenSample
+}
+
+func TestShortenSpew(t *testing.T) {
+	sample := shortenSample{
+		Name:  "abcdefghé",
+		Items: make([]int, 10),
+		Blob:  make([]byte, 100),
+		Tags:  map[string]int{"d": 4, "c": 3, "b": 2, "a": 1},
+		Next:  &shortenSample{Next: &shortenSample{}},
+	}
+	text := shortener{elements: 3, str: 8}.inspect(sample)
+	Assert(t, text, should.ContainSubstring, `Name: (string) (len=10) "abcdefgh" ... 2 more bytes ...,`)
+	Assert(t, text, should.ContainSubstring, "  (int) 0,\n  ... 7 more elements ...\n },")
+	Assert(t, text, should.ContainSubstring, "|................|\n  ... 52 more bytes ...\n },")
+	Assert(t, text, should.ContainSubstring, `"c": (int) 3,`+"\n  ... 1 more elements ...")
+	Assert(t, text, should.NotContainSubstring, `"d"`)
+
+	// multibyte runes aren't split
+	text = shortener{str: 9}.inspect(sample)
+	Assert(t, text, should.ContainSubstring, `"abcdefgh" ... 2 more bytes ...`)
+
+	text = shortener{depth: 2}.inspect(sample)
+	Assert(t, text, should.ContainSubstring, "<max depth reached>")
+
+	// no limits, no changes
+	Assert(t, shortener{}.inspect(sample), should.NotContainSubstring, " more ")
+}
+
+func TestShortenJSON(t *testing.T) {
+	doc := `{"data": [1, 2, 3, 4, 5, {"a": {"b": {}}}], "name": "abcdefghij", "empty": []}`
+	Assert(t, shortener{elements: 3, str: 5}.inspect(doc), should.Equal, strings.Join([]string{
+		`{`,
+		`  "data": [`,
+		`    1,`,
+		`    2,`,
+		`    3,`,
+		`    ... 3 more elements ...`,
+		`  ],`,
+		`  "empty": [],`,
+		`  "name": "abcde" ... 5 more bytes ...`,
+		`}`,
+		``,
+	}, "\n"))
+	Assert(t, shortener{depth: 3}.inspect(doc), should.ContainSubstring, `"a": { ... 1 keys ... }`)
+	Assert(t, shortener{elements: 1}.inspect(doc), should.ContainSubstring, "  ... 2 more keys ...\n}")
+
+	// decoded JSON is shown as the Go value it is
+	Assert(t, shortener{}.inspect(map[string]interface{}{"a": 1.0}), should.StartWith, "(map[string]interface {})")
+
+	// an unlimited shortener matches the explorer's own formatting
+	explorer, _ := should.ParseJSON(doc)
+	Assert(t, shortener{}.inspect(doc), should.Equal, explorer.String()+"\n")
+}
+
+func TestShortenBytes(t *testing.T) {
+	text := shortener{bytes: 10}.inspect(strings.Repeat("x", 100))
+	Assert(t, text, should.Equal, "(string) (\n... 112 more bytes ...\n")
+}
+
+func TestInspectLimits(t *testing.T) {
+	ft := &fakeT{name: "TestInspectLimits"}
+	With(ft, Overrides{MaxInspectElements: Int(2)})
+	defer ft.finish()
+	text := Settings(ft).Inspectv(Silent, "values", []int{1, 2, 3, 4})
+	Assert(t, text, should.StartWith, "values: \n")
+	Assert(t, text, should.ContainSubstring, "... 2 more elements ...")
+
+	// a zero limit removes the global one
+	With(ft, Overrides{MaxInspectElements: Int(0)})
+	Assert(t, Settings(ft).Inspectv(Silent, "", make([]int, MaxInspectElements+1)), should.NotContainSubstring, "more elements")
+}
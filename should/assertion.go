@@ -1,6 +1,12 @@
 package should
 
-import "fmt"
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"runtime"
+	"strings"
+)
 
 // Ok is what an Assertion returns when its condition is true.
 const Ok = ""
@@ -14,16 +20,49 @@ This concept defived from https://github.com/smartystreets/assertions
 */
 type Assertion func(actual interface{}, expected ...interface{}) (failMessage string)
 
-// Not negates the assertion it wraps.
-// BUG: it doesn't properly rename the Function
+// closureSuffix matches the ".func1" (or ".func1.2") the runtime appends to
+// the names of anonymous functions.
+var closureSuffix = regexp.MustCompile(`(\.func\d+)+(\.\d+)*$`)
+
+// AssertionName resolves the name of an assertion function at runtime, e.g.
+// "should.HaveFields" or "assertions.ShouldEqual" for the smartystreets
+// aliases. Closures come back with their enclosing function's name, so
+// assertions built by Not are named "should.Not".
+func AssertionName(a Assertion) string {
+	if a == nil {
+		return "<nil>"
+	}
+	fn := runtime.FuncForPC(reflect.ValueOf(a).Pointer())
+	if fn == nil {
+		return "<unknown>"
+	}
+	name := fn.Name()
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	return closureSuffix.ReplaceAllString(name, "")
+}
+
+// Not negates the assertion it wraps. Its failure message names the wrapped
+// assertion and shows the values it was given.
 func Not(a Assertion) Assertion {
 	return func(actual interface{}, expected ...interface{}) (fail string) {
-		failureMessage := a(actual, expected...)
-		if failureMessage == "" {
-			return "Expected a failure"
+		if a(actual, expected...) == Ok {
+			return negationFailure(AssertionName(a), actual, expected)
 		}
-		return ""
+		return Ok
+	}
+}
+
+// negationFailure explains why a negated assertion failed.
+func negationFailure(name string, actual interface{}, expected []interface{}) string {
+	args := make([]string, len(expected))
+	for i, e := range expected {
+		args[i] = fmt.Sprintf("%#v", e)
 	}
+	short := fmt.Sprintf("Expected %s to fail, but it passed.", name)
+	long := fmt.Sprintf("Not(%s) was given\nactual:   %#v\nexpected: %s", name, actual, strings.Join(args, ", "))
+	return FormatFailure(short, long, "", "")
 }
 
 // AlwaysPass succeeds no matter what.
@@ -52,7 +91,8 @@ func minimally(min int, expected []interface{}) string {
 }
 
 // FailFirst returns the first non-blank failure string in a list of
-// assertion returns.
+// assertion returns. To combine the assertions themselves, see AllOf, AnyOf,
+// and NoneOf.
 func FailFirst(msgs ...string) string {
 	for _, m := range msgs {
 		if m != "" {
@@ -42,3 +42,16 @@ func testFundamentals(t *testing.T) {
 	Passes(t, "Negated Forced Fail", Not(AlwaysFail), "FAIL!", nil, nil)
 	Fails(t, "Negated Forced Pass", Not(AlwaysPass), "PASS!", nil, nil)
 }
+
+func TestAssertionNames(t *testing.T) {
+	Passes(t, "Names local assertions", Equal, AssertionName(HaveFields), "should.HaveFields")
+	Passes(t, "Names smartystreets aliases", Equal, AssertionName(Equal), "assertions.ShouldEqual")
+	Passes(t, "Names closures after their maker", Equal, AssertionName(Not(Equal)), "should.Not")
+}
+
+func TestNotMessages(t *testing.T) {
+	short, long, _, _ := ParseFailure(Not(Equal)(1, 1))
+	Passes(t, "Names the negated assertion", Equal, short, "Expected assertions.ShouldEqual to fail, but it passed.")
+	Passes(t, "Shows the actual value", ContainSubstring, long, "actual:   1")
+	Passes(t, "Shows the expected values", ContainSubstring, long, "expected: 1")
+}
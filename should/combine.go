@@ -0,0 +1,149 @@
+package should
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Bound is an assertion with its expected arguments fixed, as a part of
+// AllOf, AnyOf, or NoneOf.
+type Bound struct {
+	Assertion Assertion
+	Expected  []interface{}
+}
+
+// Bind fixes the expected arguments of an assertion for AllOf, AnyOf, or
+// NoneOf.
+func Bind(a Assertion, expected ...interface{}) Bound {
+	return Bound{Assertion: a, Expected: expected}
+}
+
+// String describes the part, e.g. `assertions.ShouldStartWith("a")`.
+func (b Bound) String() string {
+	args := make([]string, len(b.Expected))
+	for i, e := range b.Expected {
+		args[i] = fmt.Sprintf("%#v", e)
+	}
+	return fmt.Sprintf("%s(%s)", AssertionName(b.Assertion), strings.Join(args, ", "))
+}
+
+// AllOf returns an assertion that passes if every part passes. Parts are
+// Bounds or plain assertions (which then get no expected arguments of their
+// own). Any expected arguments given to the combined assertion are passed on
+// to every part after the bound ones. Failures list each part's result.
+//
+//	Assert(t, name, AllOf(Bind(StartWith, "a"), Bind(HaveLength, 3), NotBeBlank))
+func AllOf(parts ...interface{}) Assertion {
+	return func(actual interface{}, expected ...interface{}) (fail string) {
+		c := combine("AllOf", parts, actual, expected)
+		if c.invalid != "" || c.failed == 0 {
+			return c.invalid
+		}
+		return c.failure(fmt.Sprintf("Expected all of %d assertions to pass, but %d failed.", len(c.results), c.failed))
+	}
+}
+
+// AnyOf returns an assertion that passes if at least one part passes. Parts
+// work as in AllOf.
+//
+//	Assert(t, status, AnyOf(Bind(Equal, 200), Bind(Equal, 204)))
+func AnyOf(parts ...interface{}) Assertion {
+	return func(actual interface{}, expected ...interface{}) (fail string) {
+		c := combine("AnyOf", parts, actual, expected)
+		if c.invalid != "" || c.failed < len(c.results) {
+			return c.invalid
+		}
+		return c.failure(fmt.Sprintf("Expected any of %d assertions to pass, but all failed.", len(c.results)))
+	}
+}
+
+// NoneOf returns an assertion that passes if every part fails. Parts work as
+// in AllOf.
+//
+//	Assert(t, body, NoneOf(Bind(ContainSubstring, "password"), Bind(ContainSubstring, "secret")))
+func NoneOf(parts ...interface{}) Assertion {
+	return func(actual interface{}, expected ...interface{}) (fail string) {
+		c := combine("NoneOf", parts, actual, expected)
+		passed := len(c.results) - c.failed
+		if c.invalid != "" || passed == 0 {
+			return c.invalid
+		}
+		return c.failure(fmt.Sprintf("Expected none of %d assertions to pass, but %d passed.", len(c.results), passed))
+	}
+}
+
+// partResult is the outcome of one part of a combined assertion.
+type partResult struct {
+	part Bound
+	fail string
+}
+
+// combination holds the outcomes of the parts of a combined assertion.
+type combination struct {
+	results []partResult
+	failed  int
+	invalid string // failure message for unusable parts
+}
+
+// combine runs each part against actual.
+func combine(name string, parts []interface{}, actual interface{}, expected []interface{}) (c combination) {
+	if len(parts) == 0 {
+		c.invalid = FormatFailure(name+" needs at least one assertion.", "", "", "")
+		return
+	}
+	for i, p := range parts {
+		var part Bound
+		switch x := p.(type) {
+		case Bound:
+			part = x
+		case Assertion:
+			part = Bound{Assertion: x}
+		case func(interface{}, ...interface{}) string:
+			part = Bound{Assertion: x}
+		default:
+			c.invalid = FormatFailure(
+				fmt.Sprintf("%s part %d is a %T, not an assertion.", name, i+1, p),
+				"Give each part as an Assertion or as a Bound made with Bind.", "", "")
+			return
+		}
+		args := append(append([]interface{}{}, part.Expected...), expected...)
+		fail := part.Assertion(actual, args...)
+		if fail != Ok {
+			c.failed++
+		}
+		c.results = append(c.results, partResult{part: part, fail: fail})
+	}
+	return
+}
+
+// failure formats the results of every part under short: each part's first
+// line in the long section, followed by its explanation, and the parts'
+// details and internals, numbered, in their sections.
+func (c combination) failure(short string) string {
+	var longs, details, metas []string
+	for i, r := range c.results {
+		n := i + 1
+		if r.fail == Ok {
+			longs = append(longs, fmt.Sprintf("%d. PASS %s", n, r.part))
+			continue
+		}
+		partShort, partLong, partDetails, partMeta := ParseFailure(r.fail)
+		long := fmt.Sprintf("%d. FAIL %s\n   %s", n, r.part, indent(partShort, "   "))
+		if partLong != "" {
+			long += "\n   " + indent(partLong, "   ")
+		}
+		longs = append(longs, long)
+		if partDetails != "" {
+			details = append(details, fmt.Sprintf("%d. %s", n, indent(partDetails, "   ")))
+		}
+		if partMeta != "" {
+			metas = append(metas, fmt.Sprintf("%d. %s", n, indent(partMeta, "   ")))
+		}
+	}
+	return FormatFailure(short, strings.Join(longs, "\n"), strings.Join(details, "\n"), strings.Join(metas, "\n"))
+}
+
+// indent prefixes every line after the first with prefix.
+func indent(text, prefix string) string {
+	return strings.Replace(text, "\n", "\n"+prefix, -1)
+}
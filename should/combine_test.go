@@ -0,0 +1,36 @@
+package should
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCombinators(t *testing.T) {
+	startsWithA := Bind(StartWith, "a")
+	threeLong := Bind(HaveLength, 3)
+
+	Passes(t, "AllOf passes when every part does", AllOf(startsWithA, threeLong, NotBeBlank), "abc")
+	Fails(t, "AllOf fails when any part does", AllOf(startsWithA, threeLong), "abcd")
+	Passes(t, "AnyOf passes when one part does", AnyOf(startsWithA, threeLong), "xyz")
+	Fails(t, "AnyOf fails when every part does", AnyOf(startsWithA, threeLong), "xy")
+	Passes(t, "NoneOf passes when every part fails", NoneOf(startsWithA, threeLong), "xy")
+	Fails(t, "NoneOf fails when any part passes", NoneOf(startsWithA, threeLong), "xyz")
+	Passes(t, "Combinators nest", AllOf(AnyOf(Bind(Equal, 1), Bind(Equal, 2)), Bind(NotEqual, 3)), 2)
+	Passes(t, "Expected arguments are passed on to every part", AllOf(BeGreaterThan, NotEqual), 5, 4)
+
+	Fails(t, "Parts must be assertions", AllOf("nope"), "abc")
+	Fails(t, "Parts are required", AnyOf(), "abc")
+
+	short, long, _, _ := ParseFailure(AllOf(startsWithA, threeLong, Bind(EndWith, "z"))("abcd"))
+	Passes(t, "Short says how many failed", Equal, short, "Expected all of 3 assertions to pass, but 2 failed.")
+	lines := strings.Split(long, "\n")
+	Passes(t, "Long lists passing parts", Equal, lines[0], `1. PASS assertions.ShouldStartWith("a")`)
+	Passes(t, "Long lists failing parts", Equal, lines[1], `2. FAIL assertions.ShouldHaveLength(3)`)
+	Passes(t, "Long explains failing parts", StartWith, lines[2], "   Expected collection to have length equal to")
+
+	short, long, _, _ = ParseFailure(NoneOf(startsWithA, threeLong)("abc"))
+	Passes(t, "NoneOf counts passes", Equal, short, "Expected none of 2 assertions to pass, but 2 passed.")
+	Passes(t, "NoneOf lists passes", Equal, long, "1. PASS assertions.ShouldStartWith(\"a\")\n2. PASS assertions.ShouldHaveLength(3)")
+
+	Passes(t, "Combined assertions are named", Equal, AssertionName(AnyOf(startsWithA)), "should.AnyOf")
+}
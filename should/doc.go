@@ -43,8 +43,9 @@ for those assertions aliased into this package.
 
 Future
 
-- [ ] Do we want to pass in options via flags, magic args ("option:save-golden"),
-or environment variables (SHOULD_SAVE_GOLDEN=1)?
+- [x] Do we want to pass in options via flags, magic args ("option:save-golden"),
+or environment variables (SHOULD_SAVE_GOLDEN=1)? Package variables, such as
+UpdateGolden for MatchGolden, which gotest.RegisterFlags ties to flags.
 
 */
 package should
@@ -0,0 +1,193 @@
+package should
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/Jeffail/gabs"
+)
+
+// MaxElementFailures caps how many elements the failures of Each, Any, and
+// None describe. Zero or less means no limit. gotest's
+// -gotest-element-failures flag sets it.
+var MaxElementFailures = 10
+
+// Each returns an assertion that passes if a passes for every element of a
+// slice, array, map, or JSON array or object. Any expected arguments given to
+// the returned assertion are passed on to a after the bound ones. Failures
+// list the failing elements with their own failure messages, and have the
+// path and value of the first as their Failure.Path and Actual.
+//
+//	Assert(t, body, AtPath("data", Each(HaveFields, "id", reflect.String)))
+func Each(a Assertion, expected ...interface{}) Assertion {
+	return func(actual interface{}, more ...interface{}) (fail string) {
+		elems, fail := elements("Each", actual)
+		if fail != Ok {
+			return
+		}
+		failed := checkElements(elems, a, expected, more)
+		if len(failed) == 0 {
+			return Ok
+		}
+		f := elementFailure(fmt.Sprintf("Expected each of %d elements to pass, but %d failed.", len(elems), len(failed)), failed)
+		f.Path, f.Actual = failed[0].path(), failed[0].value
+		return f.String()
+	}
+}
+
+// Any returns an assertion that passes if a passes for at least one element.
+// It works like Each, and fails for empty collections.
+//
+//	Assert(t, items, Any(HaveFields, "status", "active"))
+func Any(a Assertion, expected ...interface{}) Assertion {
+	return func(actual interface{}, more ...interface{}) (fail string) {
+		elems, fail := elements("Any", actual)
+		if fail != Ok {
+			return
+		}
+		failed := checkElements(elems, a, expected, more)
+		if len(elems) == 0 {
+			return FormatFailure("Expected any element to pass, but there are no elements.", "", "", "")
+		}
+		if len(failed) < len(elems) {
+			return Ok
+		}
+		return elementFailure(fmt.Sprintf("Expected any of %d elements to pass, but all failed.", len(elems)), failed).String()
+	}
+}
+
+// None returns an assertion that passes if a fails for every element. It
+// works like Each; failures list the elements that passed and their values.
+//
+//	Assert(t, users, None(HaveFields, "password", reflect.Interface))
+func None(a Assertion, expected ...interface{}) Assertion {
+	return func(actual interface{}, more ...interface{}) (fail string) {
+		elems, fail := elements("None", actual)
+		if fail != Ok {
+			return
+		}
+		failed := checkElements(elems, a, expected, more)
+		if len(failed) == len(elems) {
+			return Ok
+		}
+		var passed []element
+		for _, e := range elems {
+			if e.fail == Ok {
+				e.fail = fmt.Sprintf("passed with %#v", e.value)
+				passed = append(passed, e)
+			}
+		}
+		f := elementFailure(fmt.Sprintf("Expected none of %d elements to pass, but %d passed.", len(elems), len(passed)), passed)
+		f.Path, f.Actual = passed[0].label, passed[0].value
+		return f.String()
+	}
+}
+
+// element is one member of a collection checked by Each, Any, or None.
+type element struct {
+	label string // e.g. "[2]" or `["key"]`
+	value interface{}
+	fail  string
+}
+
+// path gives where in the collection e failed: its label, followed by the
+// Path of its failure, if any.
+func (e element) path() string {
+	return joinPath(e.label, AsFailure(e.fail).Path)
+}
+
+// elements lists the members of a slice, array, map (sorted by key), or JSON
+// array or object. Strings, []bytes, and parsed JSON are treated as JSON and
+// decoded, so JSON objects are checked like the maps they decode to.
+func elements(name string, actual interface{}) (elems []element, fail string) {
+	switch actual.(type) {
+	case string, *string, []byte, *gabs.Container, *GabsExplorer:
+		json, err := ParseJSON(actual)
+		if err != nil {
+			return nil, err.Error()
+		}
+		switch json.Data().(type) {
+		case []interface{}, map[string]interface{}:
+			actual = json.Data()
+		default:
+			return nil, FormatFailure(fmt.Sprintf("%s expects a JSON array or object, but got:", name), json.String(), "", "")
+		}
+	}
+	v := reflect.ValueOf(actual)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			elems = append(elems, element{label: fmt.Sprintf("[%d]", i), value: v.Index(i).Interface()})
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return lessKey(keys[i], keys[j]) })
+		for _, k := range keys {
+			elems = append(elems, element{label: fmt.Sprintf("[%#v]", k.Interface()), value: v.MapIndex(k).Interface()})
+		}
+	default:
+		return nil, FormatFailure(fmt.Sprintf("%s expects a slice, array, map, or JSON array or object, not a %T.", name, actual), "", "", "")
+	}
+	return elems, Ok
+}
+
+// lessKey orders map keys: numbers by value, strings lexically, and anything
+// else, or keys of different kinds, by how they print.
+func lessKey(a, b reflect.Value) bool {
+	if a.Kind() == reflect.Interface {
+		a = a.Elem()
+	}
+	if b.Kind() == reflect.Interface {
+		b = b.Elem()
+	}
+	if a.IsValid() && b.IsValid() && a.Kind() == b.Kind() {
+		switch a.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return a.Int() < b.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return a.Uint() < b.Uint()
+		case reflect.Float32, reflect.Float64:
+			return a.Float() < b.Float()
+		case reflect.String:
+			return a.String() < b.String()
+		}
+	}
+	return fmt.Sprintf("%#v", a) < fmt.Sprintf("%#v", b)
+}
+
+// checkElements runs a on each element, recording its result, and returns
+// the elements that failed.
+func checkElements(elems []element, a Assertion, expected, more []interface{}) (failed []element) {
+	args := append(append([]interface{}{}, expected...), more...)
+	for i := range elems {
+		elems[i].fail = a(elems[i].value, args...)
+		if elems[i].fail != Ok {
+			failed = append(failed, elems[i])
+		}
+	}
+	return
+}
+
+// elementFailure builds a failure with short as its summary, describing the
+// first MaxElementFailures of elems by their labels and failure messages.
+func elementFailure(short string, elems []element) Failure {
+	shown := elems
+	if MaxElementFailures > 0 && len(shown) > MaxElementFailures {
+		shown = shown[:MaxElementFailures]
+	}
+	lines := make([]string, 0, len(shown)+1)
+	for _, e := range shown {
+		eShort, eLong, _, _ := ParseFailure(e.fail)
+		line := fmt.Sprintf("%s %s", e.label, indent(eShort, "   "))
+		if eLong != "" {
+			line += "\n   " + indent(eLong, "   ")
+		}
+		lines = append(lines, line)
+	}
+	if n := len(elems) - len(shown); n > 0 {
+		lines = append(lines, fmt.Sprintf("... %d more ...", n))
+	}
+	return Failure{Short: short, Long: strings.Join(lines, "\n")}
+}
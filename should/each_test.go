@@ -0,0 +1,66 @@
+package should
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestElementwise(t *testing.T) {
+	Passes(t, "Each passes when every element does", Each(BeGreaterThan, 0), []int{1, 2, 3})
+	Fails(t, "Each fails when any element does", Each(BeGreaterThan, 1), []int{1, 2, 3})
+	Passes(t, "Each passes for empty collections", Each(AlwaysFail), []int{})
+	Passes(t, "Each works on arrays", Each(NotBeBlank), [2]string{"a", "b"})
+	Passes(t, "Each works on maps", Each(BeTrue), map[string]bool{"a": true, "b": true})
+	Passes(t, "Each works on JSON arrays", Each(HaveFields, "a", reflect.Float64, "d", "yes"), jsonArray)
+	Passes(t, "Expected args are passed on", Each(BeBetween, 0), []int{1, 2}, 3)
+	Passes(t, "Any passes when one element does", Any(Equal, 2), []int{1, 2, 3})
+	Fails(t, "Any fails when every element does", Any(Equal, 4), []int{1, 2, 3})
+	Fails(t, "Any fails for empty collections", Any(AlwaysPass), []int{})
+	Passes(t, "None passes when every element fails", None(Equal, 4), []int{1, 2, 3})
+	Fails(t, "None fails when any element passes", None(Equal, 2), []int{1, 2, 3})
+	Fails(t, "Non-collections fail", Each(AlwaysPass), 5)
+	Passes(t, "Each works on JSON objects", Each(NotBeNil), jsonObject)
+	Fails(t, "Other JSON fails", Each(AlwaysPass), "5")
+
+	object := `{"b": 2, "a": 1}`
+	var decoded map[string]interface{}
+	Passes(t, "...decoding", BeNil, json.Unmarshal([]byte(object), &decoded))
+	Passes(t, "JSON objects are checked like decoded ones", Equal, Each(Equal, 1.0)(object), Each(Equal, 1.0)(decoded))
+	Passes(t, "JSON object failures name keys", Equal, AsFailure(Each(Equal, 1.0)(object)).Path, `["b"]`)
+
+	_, long, _, _ := ParseFailure(Each(AlwaysFail)(map[int]bool{10: true, 2: true, 1: true}))
+	Passes(t, "Numeric keys are sorted by value", Resemble, firstWords(long), []string{"[1]", "[2]", "[10]"})
+	_, long, _, _ = ParseFailure(Each(AlwaysFail)(map[string]bool{"b10": true, "b2": true, "a": true}))
+	Passes(t, "String keys are sorted lexically", Resemble, firstWords(long), []string{`["a"]`, `["b10"]`, `["b2"]`})
+
+	short, long, _, _ := ParseFailure(Each(BeLessThan, 2)([]int{1, 2, 3}))
+	Passes(t, "Short counts failures", Equal, short, "Expected each of 3 elements to pass, but 2 failed.")
+	lines := strings.Split(long, "\n")
+	Passes(t, "Long labels failing elements", StartWith, lines[0], "[1] Expected '2' to be less than '2'")
+	Passes(t, "Long lists every failing element", StartWith, lines[len(lines)-1], "[2] ")
+	f := AsFailure(Each(BeLessThan, 2)([]int{1, 2, 3}))
+	Passes(t, "Failures have the first failing element's path", Equal, f.Path, "[1]")
+	Passes(t, "Failures have the first failing element's value", Equal, f.Actual, 2.0)
+	f = AsFailure(Each(AtPath("a", Equal, 1.0))([]string{`{"a": 1}`, `{"a": 2}`}))
+	Passes(t, "Element paths lead into the elements", Equal, f.Path, "[1].a")
+
+	_, long, _, _ = ParseFailure(None(BeTrue)(map[string]bool{"a": true, "b": false}))
+	Passes(t, "None lists passing elements by key", Equal, long, `["a"] passed with true`)
+	Passes(t, "None's failures have the first passing element's path", Equal, AsFailure(None(BeTrue)(map[string]bool{"a": true})).Path, `["a"]`)
+
+	defer func(n int) { MaxElementFailures = n }(MaxElementFailures)
+	MaxElementFailures = 2
+	_, long, _, _ = ParseFailure(Each(AlwaysFail)([]int{1, 2, 3, 4, 5}))
+	Passes(t, "Failures are capped", EndWith, long, "\n... 3 more ...")
+	Passes(t, "Capped failures show the first elements", StartWith, long, "[0] ")
+}
+
+// firstWords gives the first word of each line of text.
+func firstWords(text string) (words []string) {
+	for _, line := range strings.Split(text, "\n") {
+		words = append(words, strings.Fields(line)[0])
+	}
+	return
+}
@@ -0,0 +1,138 @@
+package should
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	// PathLabel, ActualLabel, and ExpectedLabel begin the lines of the
+	// internals section that hold a Failure's Path, Actual, and Expected.
+	PathLabel     = "# PATH: "
+	ActualLabel   = "# ACTUAL: "
+	ExpectedLabel = "# EXPECTED: "
+)
+
+// Failure is the structured form of a failure message. Assertions still
+// return strings, so an assertion that builds a Failure returns its String();
+// AsFailure recovers it, and ParseFailure still reads the string's sections.
+//
+//	return Failure{Short: "Expected a positive price.", Path: ".Lines[2].Price", Actual: price}.String()
+type Failure struct {
+	Short    string      `json:"short,omitempty"`    // one-line summary
+	Long     string      `json:"long,omitempty"`     // explanation
+	Details  string      `json:"details,omitempty"`  // debugging details
+	Meta     string      `json:"meta,omitempty"`     // internals of the assertion or runner
+	Actual   interface{} `json:"actual,omitempty"`   // the value that failed, if it's worth showing
+	Expected interface{} `json:"expected,omitempty"` // what it was compared with
+	Path     string      `json:"path,omitempty"`     // where in the actual value it failed, e.g. ".data[0].id"
+}
+
+// String formats f as a failure message (see FormatFailure). Path, Actual,
+// and Expected, when set, are added to the internals section as labeled
+// lines, with the values printed as JSON, or as by %#v if they can't be.
+func (f Failure) String() string {
+	meta := f.Meta
+	add := func(label, value string) {
+		if meta != "" {
+			meta += "\n"
+		}
+		meta += label + value
+	}
+	if f.Path != "" {
+		add(PathLabel, f.Path)
+	}
+	if f.Actual != nil {
+		add(ActualLabel, printValue(f.Actual))
+	}
+	if f.Expected != nil {
+		add(ExpectedLabel, printValue(f.Expected))
+	}
+	return FormatFailure(f.Short, f.Long, f.Details, meta)
+}
+
+// Error implements error, so a Failure can be returned or wrapped as one.
+func (f Failure) Error() string {
+	return f.String()
+}
+
+// AsFailure parses a failure message, such as one made by Failure.String or
+// FormatFailure, into a Failure. Actual and Expected come back decoded from
+// the JSON they were printed as, as by encoding/json into an interface{}, or
+// as the strings they were printed as if they weren't JSON. An empty message
+// (Ok) gives a zero Failure.
+func AsFailure(msg string) (f Failure) {
+	var meta string
+	f.Short, f.Long, f.Details, meta = ParseFailure(msg)
+	var kept []string
+	for _, line := range strings.Split(meta, "\n") {
+		switch {
+		case strings.HasPrefix(line, PathLabel):
+			f.Path = strings.TrimPrefix(line, PathLabel)
+		case strings.HasPrefix(line, ActualLabel):
+			f.Actual = parseValue(strings.TrimPrefix(line, ActualLabel))
+		case strings.HasPrefix(line, ExpectedLabel):
+			f.Expected = parseValue(strings.TrimPrefix(line, ExpectedLabel))
+		default:
+			kept = append(kept, line)
+		}
+	}
+	f.Meta = strings.Join(kept, "\n")
+	return f
+}
+
+// printValue prints an Actual or Expected value on one line for String.
+func printValue(v interface{}) string {
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return fmt.Sprintf("%#v", v)
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// parseValue reverses printValue as far as it can for AsFailure.
+func parseValue(text string) (v interface{}) {
+	if err := json.Unmarshal([]byte(text), &v); err != nil {
+		return text
+	}
+	return v
+}
+
+// joinPath appends a path within a value to the path of that value, adding a
+// "." only between two names, e.g. "data" and "[2]" give "data[2]", and
+// "data" and ".Lines" give "data.Lines".
+func joinPath(parent, child string) string {
+	switch {
+	case parent == "":
+		return child
+	case child == "" || strings.HasPrefix(child, ".") || strings.HasPrefix(child, "["):
+		return parent + child
+	}
+	return parent + "." + child
+}
+
+// labeled gives the rest of the first line of a meta section beginning with
+// label, if any.
+func labeled(meta, label string) string {
+	for _, line := range strings.Split(meta, "\n") {
+		if strings.HasPrefix(line, label) {
+			return strings.TrimPrefix(line, label)
+		}
+	}
+	return ""
+}
+
+// dropLabeled removes the lines beginning with label from a meta section.
+func dropLabeled(meta, label string) string {
+	var kept []string
+	for _, line := range strings.Split(meta, "\n") {
+		if !strings.HasPrefix(line, label) {
+			kept = append(kept, line)
+		}
+	}
+	return strings.Join(kept, "\n")
+}
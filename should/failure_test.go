@@ -0,0 +1,40 @@
+package should
+
+import "testing"
+
+func TestFailure(t *testing.T) {
+	f := Failure{Short: shortMsg, Long: longMsg, Details: detailsMsg, Meta: metaMsg}
+	Passes(t, "Failures format like FormatFailure", Equal, f.String(), FormatFailure(shortMsg, longMsg, detailsMsg, metaMsg))
+	Passes(t, "Failures round-trip", Resemble, AsFailure(f.String()), f)
+	Passes(t, "Failure messages parse as before", Resemble, AsFailure(failAll), f)
+	Passes(t, "Ok is no failure", Resemble, AsFailure(Ok), Failure{})
+
+	f = Failure{Short: shortMsg, Meta: metaMsg, Path: ".a[0]", Actual: 3, Expected: []int{4}}
+	short, _, _, meta := ParseFailure(f.String())
+	Passes(t, "ParseFailure still reads the sections", Equal, short, shortMsg)
+	Passes(t, "Extras are labeled internals", Equal, meta, metaMsg+"\n# PATH: .a[0]\n# ACTUAL: 3\n# EXPECTED: [4]")
+	g := AsFailure(f.String())
+	Passes(t, "Paths round-trip", Equal, g.Path, ".a[0]")
+	Passes(t, "Values come back decoded", Resemble, g.Expected, []interface{}{4.0})
+	Passes(t, "Meta loses the extras", Equal, g.Meta, metaMsg)
+	f = Failure{Short: shortMsg, Path: ".a", Actual: map[string]interface{}{"b": "<c>"}, Expected: 2.5}
+	Passes(t, "Values round-trip as JSON", Resemble, AsFailure(f.String()), f)
+	Passes(t, "Other values come back printed", StartWith, AsFailure(Failure{Actual: func() {}}.String()).Actual, "(func())(")
+
+	var err error = f
+	Passes(t, "Failures are errors", Equal, err.Error(), f.String())
+
+	Passes(t, "AtPath reports its path", Equal, AsFailure(AtPath("nested.x", Equal, 2.0)(jsonObject)).Path, "nested.x")
+	nested := AtPath("nested", AtPath("x", Equal, 2.0))(jsonObject)
+	Passes(t, "Nested AtPaths report the whole path", Equal, AsFailure(nested).Path, "nested.x")
+	_, _, _, meta = ParseFailure(nested)
+	Passes(t, "Nested AtPaths label one path", Equal, meta, "# AT PATH: nested.x\n# PATH: nested.x")
+
+	custom := func(actual interface{}, expected ...interface{}) string {
+		return Failure{Short: "Expected a lower price.", Path: ".Lines[2].Price"}.String()
+	}
+	f = AsFailure(AtPath("data", custom)(`{"data": {}}`))
+	Passes(t, "Other paths are joined by their separators", Equal, f.Path, "data.Lines[2].Price")
+	Passes(t, "Other paths keep the AtPath prefix", Equal, f.Short, "At Actual.data: Expected a lower price.")
+	Passes(t, "Element paths are joined without a dot", Equal, AsFailure(AtPath("b", Each(BeLessThan, 2.0))(jsonObject)).Path, "b[1]")
+}
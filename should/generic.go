@@ -0,0 +1,125 @@
+//go:build go1.18
+// +build go1.18
+
+package should
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Matcher checks actual values of type T. Match returns Ok or a failure
+// message formatted like any Assertion's (see FormatFailure). Unlike
+// Assertions, Matchers are built with their expected values, so mismatched
+// types are caught by the compiler:
+//
+//	gotest.That(t, user.Name, should.EqualTo("Ann"))
+//	gotest.That(t, len(users), should.GreaterThan(0))
+type Matcher[T any] struct {
+	Name     string        // what built it, e.g. "should.EqualTo", for reports
+	Expected []interface{} // its expected values, for reports and diffs
+	Match    func(actual T) (fail string)
+}
+
+// Ordered is satisfied by the types that support <.
+type Ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 | ~string
+}
+
+// Matching adapts an Assertion and its expected values into a Matcher for
+// values of type T, named after the Assertion.
+//
+//	should.Matching[string](HaveFields, "id", reflect.String)
+func Matching[T any](a Assertion, expected ...interface{}) Matcher[T] {
+	return Matcher[T]{
+		Name:     AssertionName(a),
+		Expected: expected,
+		Match:    func(actual T) string { return a(actual, expected...) },
+	}
+}
+
+// matcher builds a Matcher named name that checks with a and expected.
+func matcher[T any](name string, a Assertion, expected interface{}) Matcher[T] {
+	return Matcher[T]{
+		Name:     name,
+		Expected: []interface{}{expected},
+		Match:    func(actual T) string { return a(actual, expected) },
+	}
+}
+
+// EqualTo matches values equal to expected, as Equal does.
+func EqualTo[T comparable](expected T) Matcher[T] {
+	return matcher[T]("should.EqualTo", Equal, expected)
+}
+
+// NotEqualTo matches values other than expected, as NotEqual does.
+func NotEqualTo[T comparable](expected T) Matcher[T] {
+	return matcher[T]("should.NotEqualTo", NotEqual, expected)
+}
+
+// Resembling matches values deeply equal to expected, as Resemble does.
+func Resembling[T any](expected T) Matcher[T] {
+	return matcher[T]("should.Resembling", Resemble, expected)
+}
+
+// GreaterThan matches values greater than expected, as BeGreaterThan does.
+func GreaterThan[T Ordered](expected T) Matcher[T] {
+	return matcher[T]("should.GreaterThan", BeGreaterThan, expected)
+}
+
+// LessThan matches values less than expected, as BeLessThan does.
+func LessThan[T Ordered](expected T) Matcher[T] {
+	return matcher[T]("should.LessThan", BeLessThan, expected)
+}
+
+// Containing matches slices holding element, as Contain does.
+func Containing[T comparable](element T) Matcher[[]T] {
+	return matcher[[]T]("should.Containing", Contain, element)
+}
+
+// Satisfying matches values for which test returns true. description says
+// what test checks, for the failure message.
+//
+//	should.Satisfying(func(n int) bool { return n%2 == 0 }, "an even number")
+func Satisfying[T any](test func(T) bool, description string) Matcher[T] {
+	return Matcher[T]{
+		Name: "should.Satisfying",
+		Match: func(actual T) string {
+			if test(actual) {
+				return Ok
+			}
+			return FormatFailure(fmt.Sprintf("Expected %s, but got %#v.", description, actual), "", "", "")
+		},
+	}
+}
+
+// Assertion adapts m for use wherever an Assertion is expected. The
+// Assertion fails if actual isn't a T or if it's given expected values,
+// which m already has.
+func (m Matcher[T]) Assertion() Assertion {
+	return func(actual interface{}, expected ...interface{}) string {
+		if len(expected) > 0 {
+			return FormatFailure(fmt.Sprintf("A Matcher takes no right-side params, not %d.", len(expected)), "", "", "")
+		}
+		var value T
+		if actual != nil || !nilable(reflect.TypeOf(&value).Elem()) {
+			v, ok := actual.(T)
+			if !ok {
+				return FormatFailure(fmt.Sprintf("Expected a %s, not a %T.", reflect.TypeOf(&value).Elem(), actual), "", "", "")
+			}
+			value = v
+		}
+		return m.Match(value)
+	}
+}
+
+// nilable returns true if nil is a valid value of type t.
+func nilable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice:
+		return true
+	}
+	return false
+}
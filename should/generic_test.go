@@ -0,0 +1,36 @@
+//go:build go1.18
+// +build go1.18
+
+package should
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMatchers(t *testing.T) {
+	Passes(t, "EqualTo matches equal values", Equal, EqualTo(3).Match(3), Ok)
+	Passes(t, "EqualTo fails like Equal", Equal, EqualTo("a").Match("b"), Equal("b", "a"))
+	Passes(t, "NotEqualTo", Equal, NotEqualTo(3).Match(4), Ok)
+	Passes(t, "Resembling", Equal, Resembling([]int{1, 2}).Match([]int{1, 2}), Ok)
+	Passes(t, "GreaterThan", Equal, GreaterThan(2.5).Match(3), Ok)
+	Passes(t, "LessThan fails like BeLessThan", Equal, LessThan("b").Match("c"), BeLessThan("c", "b"))
+	Passes(t, "Containing", Equal, Containing("x").Match([]string{"w", "x"}), Ok)
+	even := Satisfying(func(n int) bool { return n%2 == 0 }, "an even number")
+	Passes(t, "Satisfying passes", Equal, even.Match(2), Ok)
+	short, _, _, _ := ParseFailure(even.Match(3))
+	Passes(t, "Satisfying describes failures", Equal, short, "Expected an even number, but got 3.")
+
+	fields := Matching[string](HaveFields, "id", reflect.String)
+	Passes(t, "Matching adapts Assertions", Equal, fields.Match(`{"id": "1"}`), Ok)
+
+	Passes(t, "Matchers adapt to Assertions", EqualTo(3).Assertion(), 3)
+	Fails(t, "Adapted Matchers check types", EqualTo(3).Assertion(), "3")
+	Fails(t, "Adapted Matchers take no expected values", EqualTo(3).Assertion(), 3, 3)
+	Passes(t, "Adapted Matchers accept nil for nilable types", Resembling([]int(nil)).Assertion(), nil)
+	Fails(t, "Adapted Matchers reject nil for other types", EqualTo(0).Assertion(), nil)
+
+	Passes(t, "Matchers are named after their constructors", Equal, EqualTo(1).Name, "should.EqualTo")
+	Passes(t, "Matchers hold their expected values", Resemble, Containing(1).Expected, []interface{}{1})
+	Passes(t, "Matching names Matchers after Assertions", Equal, fields.Name, "should.HaveFields")
+}
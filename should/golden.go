@@ -0,0 +1,128 @@
+package should
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+)
+
+// UpdateGolden makes MatchGolden, MatchSnapshot, and MatchBaseline rewrite
+// their files with the actual value instead of comparing against them.
+// gotest's -gotest-update flag sets it.
+var UpdateGolden bool
+
+// MatchGolden passes if actual matches the contents of the golden file named
+// by expected[0]. Strings and byte slices that hold JSON, parsed JSON
+// (StructureExplorers), and other values that encoding/json can marshal are
+// compared as JSON, ignoring formatting and key order; golden files for them
+// are stored pretty-printed. Other strings and byte slices are compared
+// exactly.
+//
+//	MatchGolden(rsp.Body, "testdata/get-user.golden")
+//
+// With UpdateGolden set, it writes actual to the golden file and passes.
+func MatchGolden(actual interface{}, expected ...interface{}) (fail string) {
+	usage := "MatchGolden expects a string, []byte, or JSON-able actual value and a golden file path as its right-side argument."
+	if msg := exactly(1, expected); msg != Ok {
+		return FormatFailure(msg, usage, "", "")
+	}
+	path, ok := expected[0].(string)
+	if !ok {
+		return FormatFailure(fmt.Sprintf("Expected the golden file path to be a string, not a %T.", expected[0]), usage, "", "")
+	}
+	content, isJSON, err := goldenContent(actual)
+	if err != nil {
+		return FormatFailure("Can't prepare actual value for golden file comparison.", err.Error(), "", "")
+	}
+
+	golden, fail := storedFile("golden file", path, content)
+	if fail != Ok || UpdateGolden {
+		return fail
+	}
+	if isJSON {
+		if sameJSON(content, golden) {
+			return Ok
+		}
+	} else if bytes.Equal(content, golden) {
+		return Ok
+	}
+	return FormatFailure(
+		fmt.Sprintf("Actual value doesn't match golden file %s.", path),
+		fmt.Sprintf("GOLDEN:\n%s\nACTUAL:\n%s", golden, content),
+		"Run the tests with -gotest-update to accept the actual value.", "")
+}
+
+// goldenContent gives the golden file representation of actual.
+func goldenContent(actual interface{}) (content []byte, isJSON bool, err error) {
+	switch v := actual.(type) {
+	case string:
+		return goldenText([]byte(v))
+	case *string:
+		return goldenText([]byte(*v))
+	case []byte:
+		return goldenText(v)
+	case StructureExplorer:
+		return prettyJSON(v.Data())
+	}
+	return prettyJSON(actual)
+}
+
+// goldenText treats text holding JSON as JSON and leaves the rest alone.
+func goldenText(text []byte) ([]byte, bool, error) {
+	var data interface{}
+	if err := json.Unmarshal(text, &data); err == nil && len(bytes.TrimSpace(text)) > 0 {
+		return prettyJSON(data)
+	}
+	return text, false, nil
+}
+
+// prettyJSON marshals data as indented JSON, leaving <, >, and & readable.
+func prettyJSON(data interface{}) ([]byte, bool, error) {
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(data); err != nil {
+		return nil, false, err
+	}
+	return buf.Bytes(), true, nil
+}
+
+// sameJSON returns true if a and b hold equivalent JSON.
+func sameJSON(a, b []byte) bool {
+	var aData, bData interface{}
+	if json.Unmarshal(a, &aData) != nil || json.Unmarshal(b, &bData) != nil {
+		return false
+	}
+	return reflect.DeepEqual(aData, bData)
+}
+
+// storedFile reads the file at path that holds the stored kind of content
+// ("golden file", "snapshot", ...) to compare against. With UpdateGolden set,
+// it writes content to the file instead, and callers should pass without
+// comparing unless that fails.
+func storedFile(kind, path string, content []byte) (stored []byte, fail string) {
+	if UpdateGolden {
+		err := os.MkdirAll(filepath.Dir(path), 0755)
+		if err == nil {
+			err = ioutil.WriteFile(path, content, 0644)
+		}
+		if err != nil {
+			return nil, FormatFailure(fmt.Sprintf("Can't update %s %s.", kind, path), err.Error(), "", "")
+		}
+		return nil, Ok
+	}
+	stored, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, FormatFailure(fmt.Sprintf("The %s %s doesn't exist.", kind, path),
+			"Run the tests with -gotest-update (or set should.UpdateGolden) to create it.", "", "")
+	}
+	if err != nil {
+		return nil, FormatFailure(fmt.Sprintf("Can't read %s %s.", kind, path), err.Error(), "", "")
+	}
+	return stored, Ok
+}
@@ -0,0 +1,37 @@
+package should
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMatchGolden(t *testing.T) {
+	dir, err := ioutil.TempDir("", "golden")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	textPath := filepath.Join(dir, "text.golden")
+	jsonPath := filepath.Join(dir, "nested", "json.golden")
+
+	Fails(t, "Missing golden files fail", MatchGolden, "hello", textPath)
+	Fails(t, "Needs a path", MatchGolden, "hello")
+
+	UpdateGolden = true
+	Passes(t, "Updating writes text", MatchGolden, "hello\n", textPath)
+	Passes(t, "Updating writes JSON", MatchGolden, `{"b": 2, "a": [1, 2]}`, jsonPath)
+	UpdateGolden = false
+
+	stored, _ := ioutil.ReadFile(jsonPath)
+	Passes(t, "JSON is stored pretty-printed", Equal, string(stored), "{\n  \"a\": [\n    1,\n    2\n  ],\n  \"b\": 2\n}\n")
+
+	Passes(t, "Text matches exactly", MatchGolden, []byte("hello\n"), textPath)
+	Fails(t, "Text differences fail", MatchGolden, "hello", textPath)
+	Passes(t, "JSON matches semantically", MatchGolden, `{"a":[1,2],"b":2}`, jsonPath)
+	Passes(t, "Values are compared as JSON", MatchGolden, map[string]interface{}{"a": []int{1, 2}, "b": 2}, jsonPath)
+	explorer, _ := ParseJSON(`{"b": 2, "a": [1, 2]}`)
+	Passes(t, "Explorers are compared as JSON", MatchGolden, explorer, jsonPath)
+	Fails(t, "JSON differences fail", MatchGolden, `{"a":[2,1],"b":2}`, jsonPath)
+}
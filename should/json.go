@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"strings"
 
 	"github.com/Jeffail/gabs"
 )
@@ -44,6 +45,8 @@ func parseJSON(actual interface{}) (*gabs.Container, error) {
 		return v, nil
 	case *GabsExplorer: // until we convert the other tests over to use StructureExplorers
 		return (*gabs.Container)(v), nil
+	case map[string]interface{}, []interface{}: // already decoded, e.g. by encoding/json or AtPath
+		return gabs.Consume(v)
 	default:
 		return nil, fmt.Errorf(
 			FormatFailure(
@@ -181,7 +184,8 @@ func haveOnlyKeys(json *gabs.Container, allowed ...interface{}) (fail string) {
 	return
 }
 
-// BeJSON asserts that the first argument can be parsed as JSON.
+// BeJSON asserts that the first argument can be parsed as JSON, or is JSON
+// already decoded into a map[string]interface{} or []interface{}.
 func BeJSON(actual interface{}, expected ...interface{}) (fail string) {
 	usage := "BeJson expects a single string argument and passes if that argument parses as JSON."
 	if actual == nil {
@@ -436,3 +440,85 @@ func CountAtLeast(actual interface{}, args ...interface{}) (fail string) {
 	}
 	return
 }
+
+// atPathLabel begins the internals line naming the whole path of a failure
+// AtPath built, so that an enclosing AtPath can rename it from the top.
+const atPathLabel = "# AT PATH: "
+
+// AtPath returns an assertion that runs a on the part of the JSON at path
+// (see StructureExplorer.GetPathCheck), passing the part's decoded Data() as
+// the actual value. Any expected arguments given to the returned assertion
+// are passed on after the bound ones. An empty path checks the whole value.
+// Failures carry the path as their Failure.Path, followed by the Path of a's
+// failure, if any. Nested AtPaths are named by their whole path, e.g.
+// Actual.data.id for AtPath("data", AtPath("id", ...)).
+//
+//   Assert(t, body, AtPath("data.attributes", HaveFields, "name", reflect.String))
+//   Assert(t, body, AtPath("meta.total", Equal, 3.0))
+func AtPath(path string, a Assertion, expected ...interface{}) Assertion {
+	return func(actual interface{}, more ...interface{}) (fail string) {
+		json, err := ParseJSON(actual)
+		if err != nil {
+			return err.Error()
+		}
+		data := json
+		if path != "" {
+			var ok bool
+			if data, ok = json.GetPathCheck(path); !ok {
+				return Failure{
+					Short: missingAt(path),
+					Long:  json.String(),
+					Meta:  atPathLabel + path,
+					Path:  path,
+				}.String()
+			}
+		}
+		fail = a(data.Data(), append(append([]interface{}{}, expected...), more...)...)
+		if fail == Ok {
+			return
+		}
+		short, long, details, meta := ParseFailure(fail)
+		inner := AsFailure(fail).Path
+		full := joinPath(path, inner)
+		if inner != "" && labeled(meta, atPathLabel) == inner {
+			// a nested AtPath's failure: name its subtree from the top
+			meta = dropLabeled(dropLabeled(meta, PathLabel), atPathLabel)
+			if short == missingAt(inner) {
+				short = missingAt(full)
+			} else {
+				short = atPathShort(full, strings.TrimPrefix(short, atPathShort(inner, "")))
+				lines := strings.Split(long, "\n")
+				last := len(lines) - 1
+				lines[last] = whereAt(full) + strings.TrimPrefix(lines[last], whereAt(inner))
+				long = strings.Join(lines, "\n")
+			}
+		} else {
+			meta = dropLabeled(meta, PathLabel)
+			short = atPathShort(path, short)
+		}
+		if long != "" {
+			long += "\n"
+		}
+		long += fmt.Sprintf("%s = %s", whereAt(path), data)
+		if meta != "" {
+			meta += "\n"
+		}
+		meta += atPathLabel + full
+		return Failure{Short: short, Long: long, Details: details, Meta: meta, Path: full}.String()
+	}
+}
+
+// whereAt names the part of the actual value at a path.
+func whereAt(path string) string {
+	return joinPath("Actual", path)
+}
+
+// atPathShort prefixes a short failure message with the path it's about.
+func atPathShort(path, short string) string {
+	return fmt.Sprintf("At %s: %s", whereAt(path), short)
+}
+
+// missingAt is the short failure message for a path that isn't there.
+func missingAt(path string) string {
+	return fmt.Sprintf("Expected %s to exist, but it's missing or null.", whereAt(path))
+}
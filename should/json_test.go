@@ -1,6 +1,7 @@
 package should
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
 )
@@ -17,6 +18,7 @@ func TestJSON(t *testing.T) {
 	t.Run("should.BeJSON", testBeJSON)
 	t.Run("should.HaveFields", testHaveFields)
 	t.Run("should.HaveOnlyCamelcaseKeys", testCamelcaseKeys)
+	t.Run("should.AtPath", testAtPath)
 }
 
 func testHaveFields(t *testing.T) {
@@ -32,6 +34,11 @@ func testHaveFields(t *testing.T) {
 	Fails(t, "Only have fields pass", HaveOnlyFields, jsonObject, "a", reflect.String)
 	Fails(t, "Only have fields pass", HaveOnlyFields, jsonObject, "z", reflect.String)
 
+	var decoded map[string]interface{}
+	Passes(t, "...decoding", BeNil, json.Unmarshal([]byte(jsonObject), &decoded))
+	Passes(t, "Decoded objects have fields", HaveFields, decoded, simpleFields...)
+	Fails(t, "Decoded objects can lack fields", HaveFields, decoded, wrongFields...)
+
 	// Test documentation
 	Passes(t, "Self documents if passed nil", StartWith, HaveFields(nil), "HaveFields expects")
 }
@@ -42,12 +49,15 @@ func testBeJSON(t *testing.T) {
 	Passes(t, "A compound object should parse", BeJSON, jsonObject)
 	Passes(t, "An array should parse", BeJSON, jsonArray)
 	Passes(t, "An []byte of the same array should parse", BeJSON, []byte(jsonArray))
+	Passes(t, "A decoded object should pass", BeJSON, map[string]interface{}{"a": 1.0})
+	Passes(t, "A decoded array should pass", BeJSON, []interface{}{1.0, "b"})
 
 	// Test failing paths.
 	Fails(t, "Malformed objects should fail", BeJSON, jsonSimpleObject[1:])
 	Fails(t, "Malformed ARRAYS should fail", BeJSON, jsonArray[:len(jsonArray)-1])
 	Fails(t, "Nil fails", BeJSON, nil)
 	Fails(t, "Non-strings fail", BeJSON, 5)
+	Fails(t, "Other maps fail", BeJSON, map[string]int{"a": 1})
 
 	// Test re-use of container.
 	parsedJSONContainer, err := parseJSON(jsonArray)
@@ -64,3 +74,31 @@ func testCamelcaseKeys(t *testing.T) {
 	Passes(t, "An array holding objects ignoring it's snake_case keys should pass", HaveOnlyCamelcaseKeys, snakeyArray, "snake_case")
 	Fails(t, "Asking the camelcaser to ignore a non-string fails.", HaveOnlyCamelcaseKeys, jsonObject, make(map[int]int))
 }
+
+func testAtPath(t *testing.T) {
+	Passes(t, "A subtree is checked", AtPath("nested.x", Equal, 1.0), jsonObject)
+	Passes(t, "Expected args are passed on", AtPath("b", HaveLength), jsonObject, 3)
+	Passes(t, "JSON assertions work on subtrees", AtPath("nested", HaveFields, "camelCase", reflect.Bool), jsonObject)
+	Passes(t, "An empty path checks everything", AtPath("", HaveLength, 3), jsonArray)
+	Fails(t, "A failing subtree fails", AtPath("d", Equal, "no"), jsonObject)
+	Fails(t, "A missing path fails", AtPath("nested.y", BeNil), jsonObject)
+	Fails(t, "Unparseable JSON fails", AtPath("a", AlwaysPass), "{")
+
+	short, long, _, _ := ParseFailure(AtPath("nested.x", Equal, 2.0)(jsonObject))
+	Passes(t, "Failures name the path", StartWith, short, "At Actual.nested.x: Expected: '2'")
+	Passes(t, "Failures show the subtree", EndWith, long, "Actual.nested.x = 1")
+	short, _, _, _ = ParseFailure(AtPath("nested.y", BeNil)(jsonObject))
+	Passes(t, "Missing paths are named", Equal, short, "Expected Actual.nested.y to exist, but it's missing or null.")
+
+	short, long, _, _ = ParseFailure(AtPath("nested", AtPath("x", Equal, 2.0))(jsonObject))
+	Passes(t, "Nested paths are named from the top", Equal, short, "At Actual.nested.x: Expected: '2'")
+	Passes(t, "Nested subtrees are shown", ContainSubstring, long, "\nActual.nested.x = 1\nActual.nested = {")
+	short, _, _, _ = ParseFailure(AtPath("nested", AtPath("y", BeNil))(jsonObject))
+	Passes(t, "Nested missing paths are named from the top", Equal, short, "Expected Actual.nested.y to exist, but it's missing or null.")
+	short, _, _, _ = ParseFailure(AtPath("", AtPath("nested", AtPath("x", Equal, 2.0)))(jsonObject))
+	Passes(t, "Paths nest at any depth", Equal, short, "At Actual.nested.x: Expected: '2'")
+
+	// only failures AtPath built are renamed, not what assertions say
+	short, _, _, _ = ParseFailure(AtPath("a", AtPath("x", Equal, "Actual.x"))(`{"a": {"x": "y"}}`))
+	Passes(t, "Values naming paths are left alone", Equal, short, "At Actual.a.x: Expected: 'Actual.x'")
+}
@@ -2,6 +2,7 @@ package should
 
 import (
 	"strings"
+	"unicode/utf8"
 )
 
 // tools for working with failure messages
@@ -11,8 +12,9 @@ const (
 	// portion)
 	ShortSeparator = "\n"
 
-	// ShortLength is an arbitrary length used to shorten failure messages that
-	// don't contain ShortSeparator
+	// ShortLength is an arbitrary length (in bytes, backing up to a whole UTF-8
+	// character) used to shorten failure messages that don't contain
+	// ShortSeparator
 	ShortLength = 80
 
 	// LongSeparator ends the failure message explanation section (and begins the
@@ -36,13 +38,19 @@ func splitShortLong(s string) (short, long string) {
 	if len(sl) > 1 {
 		return trim(sl[0]), trim(sl[1])
 	}
+	s = sl[0]
 	if len(s) > ShortLength {
-		return trim(s[:ShortLength]), trim(s[ShortLength:])
+		cut := ShortLength
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		return trim(s[:cut]), trim(s[cut:])
 	}
-	return trim(s), ""
+	return s, ""
 }
 
-// ParseFailure divides a failure message into parts that may be muted depending on verbosity levels
+// ParseFailure divides a failure message into parts that may be muted depending on verbosity levels.
+// See AsFailure for the structured form.
 func ParseFailure(msg string) (short, long, details, meta string) {
 	if msg == "" {
 		return
@@ -58,7 +66,8 @@ func ParseFailure(msg string) (short, long, details, meta string) {
 	return
 }
 
-// FormatFailure creates a failure message from its components
+// FormatFailure creates a failure message from its components. See Failure
+// for the structured form.
 func FormatFailure(short, long, details, meta string) (result string) {
 	result = short + ShortSeparator + long + SectionSeparator + details + SectionSeparator + meta
 	return
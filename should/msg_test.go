@@ -1,6 +1,10 @@
 package should
 
-import "testing"
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
 
 const (
 	shortMsg      = "Brief message"
@@ -56,4 +60,12 @@ func TestFailureMessageParsing(t *testing.T) {
 	testMessageParse(t, "", shortMsg, "", "", "")
 	testMessageParse(t, "something\n"+longMsg, "something", longMsg, "", "")
 	testMessageParse(t, " \n \n \n "+longMsg, "Extra message with", "several lines.", "", "")
+
+	// long one-line messages are cut between UTF-8 characters
+	runes := "a" + strings.Repeat("é", ShortLength)
+	short, long := splitShortLong(runes)
+	testStringEqual(t, short+long, runes)
+	if !utf8.ValidString(short) || !utf8.ValidString(long) {
+		t.Errorf("splitShortLong cut inside a character: %q", short)
+	}
 }
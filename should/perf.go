@@ -0,0 +1,173 @@
+package should
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	// AllocationRuns is how many times AllocateAtMost calls its function to
+	// average the allocations.
+	AllocationRuns = 100
+
+	// TimingRuns is how many times TakeAtMost calls its function to average
+	// the time per call.
+	TimingRuns = 100
+)
+
+// Performance describes the per-operation cost of a benchmarked function.
+type Performance struct {
+	NsPerOp     float64 `json:"nsPerOp"`
+	AllocsPerOp float64 `json:"allocsPerOp"`
+	BytesPerOp  float64 `json:"bytesPerOp"`
+}
+
+// AllocateAtMost passes if the func() given as actual makes no more than
+// expected[0] heap allocations per call, on average over AllocationRuns calls
+// (see testing.AllocsPerRun).
+//
+//	AllocateAtMost(func() { parse(input) }, 3)
+func AllocateAtMost(actual interface{}, expected ...interface{}) (fail string) {
+	usage := "AllocateAtMost expects a func() and the most allocations it may make per call."
+	fn, budget, msg := budgetArgs(actual, expected)
+	if msg != Ok {
+		return FormatFailure(msg, usage, "", "")
+	}
+	allocs := testing.AllocsPerRun(AllocationRuns, fn)
+	if allocs > budget {
+		return FormatFailure(
+			fmt.Sprintf("Expected at most %v allocations per call, but averaged %v.", budget, allocs),
+			fmt.Sprintf("Averaged over %d calls.", AllocationRuns), "", "")
+	}
+	return Ok
+}
+
+// TakeAtMost passes if the func() given as actual takes no longer than the
+// time.Duration expected[0] per call, on average over TimingRuns calls after
+// one warm-up call.
+//
+//	TakeAtMost(func() { parse(input) }, 50*time.Microsecond)
+func TakeAtMost(actual interface{}, expected ...interface{}) (fail string) {
+	usage := "TakeAtMost expects a func() and a time.Duration it may take per call."
+	fn, ok := actual.(func())
+	if !ok {
+		return FormatFailure(fmt.Sprintf("Expected a func(), not a %T.", actual), usage, "", "")
+	}
+	if msg := exactly(1, expected); msg != Ok {
+		return FormatFailure(msg, usage, "", "")
+	}
+	budget, ok := expected[0].(time.Duration)
+	if !ok {
+		return FormatFailure(fmt.Sprintf("Expected a time.Duration budget, not a %T.", expected[0]), usage, "", "")
+	}
+	fn()
+	start := time.Now()
+	for i := 0; i < TimingRuns; i++ {
+		fn()
+	}
+	perCall := time.Since(start) / TimingRuns
+	if perCall > budget {
+		return FormatFailure(
+			fmt.Sprintf("Expected at most %s per call, but averaged %s.", budget, perCall),
+			fmt.Sprintf("Averaged over %d calls.", TimingRuns), "", "")
+	}
+	return Ok
+}
+
+// budgetArgs checks the arguments of AllocateAtMost.
+func budgetArgs(actual interface{}, expected []interface{}) (fn func(), budget float64, msg string) {
+	fn, ok := actual.(func())
+	if !ok {
+		return nil, 0, fmt.Sprintf("Expected a func(), not a %T.", actual)
+	}
+	if msg = exactly(1, expected); msg != Ok {
+		return
+	}
+	budget, ok = toFloat(expected[0])
+	if !ok {
+		return nil, 0, fmt.Sprintf("Expected a numeric budget, not a %T.", expected[0])
+	}
+	return
+}
+
+// toFloat converts any integer or floating point number to a float64.
+func toFloat(v interface{}) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
+
+// MatchBaseline passes if the Performance given as actual is no more than
+// expected[1] percent worse, in time, allocations, or bytes per operation,
+// than the baseline stored as JSON in the file named by expected[0]. With
+// UpdateGolden set, it records actual as the new baseline and passes.
+//
+//	MatchBaseline(perf, "testdata/BenchmarkParse.baseline.json", 10)
+func MatchBaseline(actual interface{}, expected ...interface{}) (fail string) {
+	usage := "MatchBaseline expects a Performance, a baseline file path, and a tolerance percentage."
+	perf, ok := actual.(Performance)
+	if !ok {
+		return FormatFailure(fmt.Sprintf("Expected a should.Performance, not a %T.", actual), usage, "", "")
+	}
+	if msg := exactly(2, expected); msg != Ok {
+		return FormatFailure(msg, usage, "", "")
+	}
+	path, ok := expected[0].(string)
+	if !ok {
+		return FormatFailure(fmt.Sprintf("Expected the baseline path to be a string, not a %T.", expected[0]), usage, "", "")
+	}
+	tolerance, ok := toFloat(expected[1])
+	if !ok {
+		return FormatFailure(fmt.Sprintf("Expected a numeric tolerance percentage, not a %T.", expected[1]), usage, "", "")
+	}
+
+	content, _, err := prettyJSON(perf)
+	if err != nil {
+		return FormatFailure("Can't encode the performance as JSON.", err.Error(), "", "")
+	}
+	stored, fail := storedFile("baseline", path, content)
+	if fail != Ok || UpdateGolden {
+		return fail
+	}
+	var baseline Performance
+	if err = json.Unmarshal(stored, &baseline); err != nil {
+		return FormatFailure(fmt.Sprintf("Baseline %s isn't valid JSON.", path), err.Error(), "", "")
+	}
+
+	var over []string
+	check := func(metric string, got, want float64) {
+		if got > want*(1+tolerance/100) {
+			over = append(over, fmt.Sprintf("%s: %.4g, baseline %.4g (%+.1f%%)", metric, got, want, percentChange(got, want)))
+		}
+	}
+	check("ns/op", perf.NsPerOp, baseline.NsPerOp)
+	check("allocs/op", perf.AllocsPerOp, baseline.AllocsPerOp)
+	check("B/op", perf.BytesPerOp, baseline.BytesPerOp)
+	if len(over) == 0 {
+		return Ok
+	}
+	return FormatFailure(
+		fmt.Sprintf("Performance is more than %v%% worse than baseline %s in %s.", tolerance, path, strings.Join(over, "; ")),
+		fmt.Sprintf("ACTUAL:   %.4g ns/op, %.4g allocs/op, %.4g B/op\nBASELINE: %.4g ns/op, %.4g allocs/op, %.4g B/op",
+			perf.NsPerOp, perf.AllocsPerOp, perf.BytesPerOp, baseline.NsPerOp, baseline.AllocsPerOp, baseline.BytesPerOp),
+		"Run the benchmarks with -gotest-update to accept the new performance.", "")
+}
+
+// percentChange gives how much larger got is than want, in percent.
+func percentChange(got, want float64) float64 {
+	if want == 0 {
+		return 100
+	}
+	return (got - want) / want * 100
+}
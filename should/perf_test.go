@@ -0,0 +1,57 @@
+package should
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var sink []byte
+
+func TestAllocateAtMost(t *testing.T) {
+	noAllocs := func() {}
+	twoAllocs := func() {
+		sink = make([]byte, 64)
+		sink = make([]byte, 128)
+	}
+	Passes(t, "No allocations are within budget", AllocateAtMost, noAllocs, 0)
+	Passes(t, "Allocations within budget pass", AllocateAtMost, twoAllocs, 2)
+	Fails(t, "Allocations over budget fail", AllocateAtMost, twoAllocs, 1.5)
+	Fails(t, "Needs a func()", AllocateAtMost, "forced", 1)
+	Fails(t, "Needs a numeric budget", AllocateAtMost, noAllocs, "1")
+}
+
+func TestTakeAtMost(t *testing.T) {
+	quick := func() {}
+	slow := func() { time.Sleep(time.Millisecond) }
+	Passes(t, "Quick calls are within budget", TakeAtMost, quick, 10*time.Millisecond)
+	Fails(t, "Slow calls fail", TakeAtMost, slow, 100*time.Microsecond)
+	Fails(t, "Needs a duration", TakeAtMost, quick, 5)
+}
+
+func TestMatchBaseline(t *testing.T) {
+	dir, err := ioutil.TempDir("", "baseline")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "bench", "BenchmarkX.baseline.json")
+	recorded := Performance{NsPerOp: 100, AllocsPerOp: 2, BytesPerOp: 64}
+
+	Fails(t, "Missing baselines fail", MatchBaseline, recorded, path, 10)
+	UpdateGolden = true
+	Passes(t, "Updating records the baseline", MatchBaseline, recorded, path, 10)
+	UpdateGolden = false
+
+	Passes(t, "Same performance passes", MatchBaseline, recorded, path, 10)
+	Passes(t, "Better performance passes", MatchBaseline, Performance{NsPerOp: 50}, path, 10)
+	Passes(t, "Slightly worse performance is tolerated", MatchBaseline, Performance{NsPerOp: 109, AllocsPerOp: 2, BytesPerOp: 70}, path, 10)
+	Fails(t, "Slower performance fails", MatchBaseline, Performance{NsPerOp: 111, AllocsPerOp: 2, BytesPerOp: 64}, path, 10)
+	Fails(t, "More allocations fail", MatchBaseline, Performance{NsPerOp: 100, AllocsPerOp: 3, BytesPerOp: 64}, path, 10)
+	Passes(t, "Tolerance is configurable", MatchBaseline, Performance{NsPerOp: 150, AllocsPerOp: 3, BytesPerOp: 64}, path, 50)
+
+	_, long, _, _ := ParseFailure(MatchBaseline(Performance{NsPerOp: 200, AllocsPerOp: 2, BytesPerOp: 64}, path, 10))
+	Passes(t, "Failures compare the numbers", ContainSubstring, long, "BASELINE: 100 ns/op, 2 allocs/op, 64 B/op")
+}
@@ -0,0 +1,128 @@
+package should
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	registryMu sync.RWMutex
+	registry   = builtins()
+)
+
+// builtins lists the assertions of this package, including the smartystreets
+// aliases, by name.
+func builtins() map[string]Assertion {
+	return map[string]Assertion{
+		"AllocateAtMost":              AllocateAtMost,
+		"AllowFields":                 AllowFields,
+		"AlmostEqual":                 AlmostEqual,
+		"AlwaysFail":                  AlwaysFail,
+		"AlwaysPass":                  AlwaysPass,
+		"BeBetween":                   BeBetween,
+		"BeBetweenOrEqual":            BeBetweenOrEqual,
+		"BeBlank":                     BeBlank,
+		"BeChronological":             BeChronological,
+		"BeEmpty":                     BeEmpty,
+		"BeFalse":                     BeFalse,
+		"BeGreaterThan":               BeGreaterThan,
+		"BeGreaterThanOrEqualTo":      BeGreaterThanOrEqualTo,
+		"BeIn":                        BeIn,
+		"BeJSON":                      BeJSON,
+		"BeJSONAPI":                   BeJSONAPI,
+		"BeJSONAPIArray":              BeJSONAPIArray,
+		"BeJSONAPIRecord":             BeJSONAPIRecord,
+		"BeJSONAPIResourceIdentifier": BeJSONAPIResourceIdentifier,
+		"BeJsonapiError":              BeJsonapiError,
+		"BeLessThan":                  BeLessThan,
+		"BeLessThanOrEqualTo":         BeLessThanOrEqualTo,
+		"BeNil":                       BeNil,
+		"BeSortedByField":             BeSortedByField,
+		"BeTrue":                      BeTrue,
+		"BeZeroValue":                 BeZeroValue,
+		"Contain":                     Contain,
+		"ContainKey":                  ContainKey,
+		"ContainSubstring":            ContainSubstring,
+		"CountAtLeast":                CountAtLeast,
+		"EndWith":                     EndWith,
+		"Equal":                       Equal,
+		"EqualTrimSpace":              EqualTrimSpace,
+		"EqualWithout":                EqualWithout,
+		"HappenAfter":                 HappenAfter,
+		"HappenBefore":                HappenBefore,
+		"HappenBetween":               HappenBetween,
+		"HappenOnOrAfter":             HappenOnOrAfter,
+		"HappenOnOrBefore":            HappenOnOrBefore,
+		"HappenOnOrBetween":           HappenOnOrBetween,
+		"HappenWithin":                HappenWithin,
+		"HaveFields":                  HaveFields,
+		"HaveLength":                  HaveLength,
+		"HaveOnlyCamelcaseKeys":       HaveOnlyCamelcaseKeys,
+		"HaveOnlyFields":              HaveOnlyFields,
+		"HaveSameTypeAs":              HaveSameTypeAs,
+		"Implement":                   Implement,
+		"MatchBaseline":               MatchBaseline,
+		"MatchGolden":                 MatchGolden,
+		"MatchHTTPStatusCode":         MatchHTTPStatusCode,
+		"MatchSnapshot":               MatchSnapshot,
+		"NotAlmostEqual":              NotAlmostEqual,
+		"NotBeBetween":                NotBeBetween,
+		"NotBeBetweenOrEqual":         NotBeBetweenOrEqual,
+		"NotBeBlank":                  NotBeBlank,
+		"NotBeEmpty":                  NotBeEmpty,
+		"NotBeIn":                     NotBeIn,
+		"NotBeNil":                    NotBeNil,
+		"NotContain":                  NotContain,
+		"NotContainKey":               NotContainKey,
+		"NotContainSubstring":         NotContainSubstring,
+		"NotEndWith":                  NotEndWith,
+		"NotEqual":                    NotEqual,
+		"NotHappenOnOrBetween":        NotHappenOnOrBetween,
+		"NotHappenWithin":             NotHappenWithin,
+		"NotHaveSameTypeAs":           NotHaveSameTypeAs,
+		"NotImplement":                NotImplement,
+		"NotJSONAPIError":             NotJSONAPIError,
+		"NotPanic":                    NotPanic,
+		"NotPanicWith":                NotPanicWith,
+		"NotPointTo":                  NotPointTo,
+		"NotResemble":                 NotResemble,
+		"NotStartWith":                NotStartWith,
+		"Panic":                       Panic,
+		"PanicWith":                   PanicWith,
+		"PointTo":                     PointTo,
+		"Resemble":                    Resemble,
+		"StartWith":                   StartWith,
+		"TakeAtMost":                  TakeAtMost,
+	}
+}
+
+// Register makes an assertion available to Lookup under name, replacing any
+// assertion already registered under it. Every assertion in this package is
+// registered under its own name, e.g. "Equal" or "HaveFields".
+func Register(name string, a Assertion) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[name] = a
+}
+
+// Lookup finds an assertion by the name it was registered under. A "should."
+// prefix is ignored, so names given by AssertionName for this package's own
+// assertions work too.
+func Lookup(name string) (a Assertion, ok bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	a, ok = registry[strings.TrimPrefix(name, "should.")]
+	return
+}
+
+// Registered lists the names of all registered assertions in order.
+func Registered() (names []string) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return
+}
@@ -0,0 +1,38 @@
+package should
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+	for _, name := range []string{"Equal", "Resemble", "HaveFields", "MatchSnapshot", "should.BeJSONAPI"} {
+		if _, ok := Lookup(name); !ok {
+			t.Errorf("Expected %s to be registered", name)
+		}
+	}
+	if _, ok := Lookup("Bogus"); ok {
+		t.Error("Expected unregistered names not to be found")
+	}
+
+	// every smartystreets alias is registered under its own name
+	equal, _ := Lookup("Equal")
+	Passes(t, "Registered assertions work", equal, 1, 1)
+	Fails(t, "Registered assertions work", equal, 1, 2)
+
+	Register("BeForty", func(actual interface{}, expected ...interface{}) string {
+		return Equal(actual, 40)
+	})
+	defer func() {
+		registryMu.Lock()
+		delete(registry, "BeForty")
+		registryMu.Unlock()
+	}()
+	beForty, ok := Lookup("BeForty")
+	Passes(t, "Custom assertions can be registered", BeTrue, ok)
+	Passes(t, "Custom assertions work", beForty, 40)
+
+	names := Registered()
+	Passes(t, "Registered names are listed", Contain, names, "BeForty")
+	Passes(t, "Registered names are sorted", BeTrue, reflect.DeepEqual(names[:2], []string{"AllocateAtMost", "AllowFields"}))
+}
@@ -2,6 +2,7 @@ package should
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"net/http"
 	"testing"
@@ -15,8 +16,14 @@ type RESTHarness struct {
 	API       rest.Describer
 	Requester RequestMaker
 	Parser    StructureParser
+	Context   context.Context // if nil, RequestContext
 }
 
+// RequestContext is given to the requests of RESTHarnesses without a Context
+// of their own. gotest sets it to gotest.Context(), so that requests stop
+// when -gotest-abort aborts the run.
+var RequestContext = context.Background()
+
 // RESTExchange holds one HTTP request, expected response, and actual response
 type RESTExchange struct {
 	Request  *http.Request   // The request
@@ -65,6 +72,15 @@ func (har *RESTHarness) RunRequest(requestID string, body string, params ...stri
 	if result.Err != nil {
 		return
 	}
+	ctx := har.Context
+	if ctx == nil {
+		ctx = RequestContext
+	}
+	if result.Err = ctx.Err(); result.Err != nil {
+		result.Err = fmt.Errorf("request %s not run: %s", requestID, result.Err)
+		return
+	}
+	result.Request = result.Request.WithContext(ctx)
 
 	if expected != nil {
 		result.Expected, result.Err = ReadResponseBody(expected, har.Parser)
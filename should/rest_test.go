@@ -1,6 +1,13 @@
 package should
 
-import "testing"
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/kindrid/gotest/rest"
+)
 
 func TestRestfulHarness(t *testing.T) {
 	har := RESTHarness{}
@@ -8,3 +15,41 @@ func TestRestfulHarness(t *testing.T) {
 		t.Error("We're just making things compile.")
 	}
 }
+
+// oneRequest describes a single GET request.
+type oneRequest struct {
+	rest.Describer
+}
+
+func (oneRequest) GetRequest(requestID string, body string, params ...string) (*http.Request, *http.Response, error) {
+	req, err := http.NewRequest("GET", "http://example.com/"+requestID, nil)
+	return req, &http.Response{StatusCode: 200}, err
+}
+
+func TestHarnessContext(t *testing.T) {
+	ran := false
+	requester := func(req *http.Request) (*http.Response, error) {
+		ran = true
+		return &http.Response{StatusCode: 200}, req.Context().Err()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	har := RESTHarness{API: oneRequest{}, Requester: requester, Parser: ParseJSONExplorer, Context: ctx}
+	if ex := har.RunRequest("users", ""); ex.Err != nil || !ran {
+		t.Errorf("Expected the request to run, got %v", ex.Err)
+	}
+
+	cancel()
+	ran = false
+	ex := har.RunRequest("users", "")
+	if ran || ex.Err == nil || !strings.Contains(ex.Err.Error(), "request users not run: context canceled") {
+		t.Errorf("Expected a canceled context to stop the request, got %v", ex.Err)
+	}
+
+	saved := RequestContext
+	defer func() { RequestContext = saved }()
+	RequestContext = ctx
+	har.Context = nil
+	if ex := har.RunRequest("users", ""); ex.Err == nil || ran {
+		t.Errorf("Expected harnesses without a Context to use RequestContext")
+	}
+}
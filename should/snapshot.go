@@ -0,0 +1,280 @@
+package should
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Normalizer rewrites the volatile parts of a decoded JSON document (ids,
+// timestamps, ETags, ...) so that MatchSnapshot can compare it from run to
+// run. It receives and returns the document as decoded by encoding/json.
+type Normalizer func(doc interface{}) interface{}
+
+// Placeholder replaces the values found at path with placeholder. Paths are
+// dot separated like StructureExplorer paths; a "*" segment matches every key
+// or element, and other segments are applied to each element of an array, so
+// "data.id" covers the id of every record in a JSON:API array response.
+func Placeholder(path string, placeholder interface{}) Normalizer {
+	return func(doc interface{}) interface{} {
+		return rewritePath(doc, splitPath(path), func(interface{}) interface{} { return placeholder })
+	}
+}
+
+// SortBy sorts the array(s) found at path by each element's key field. An
+// empty path sorts the document itself.
+func SortBy(path, key string) Normalizer {
+	return func(doc interface{}) interface{} {
+		return rewritePath(doc, splitPath(path), func(v interface{}) interface{} {
+			array, ok := v.([]interface{})
+			if !ok {
+				return v
+			}
+			sort.SliceStable(array, func(i, j int) bool {
+				return lessJSON(fieldOf(array[i], key), fieldOf(array[j], key))
+			})
+			return array
+		})
+	}
+}
+
+// RoundFloats rounds every number in the document to places decimal places.
+func RoundFloats(places int) Normalizer {
+	scale := math.Pow(10, float64(places))
+	var round func(v interface{}) interface{}
+	round = func(v interface{}) interface{} {
+		switch x := v.(type) {
+		case float64:
+			return math.Round(x*scale) / scale
+		case []interface{}:
+			for i := range x {
+				x[i] = round(x[i])
+			}
+		case map[string]interface{}:
+			for k := range x {
+				x[k] = round(x[k])
+			}
+		}
+		return v
+	}
+	return round
+}
+
+// MatchSnapshot passes if actual, once normalized, matches the snapshot file
+// named by expected[0]. Any further right-side arguments are Normalizers,
+// applied in order. actual may be anything ParseJSON understands. Snapshots
+// are stored normalized and pretty-printed; with UpdateGolden set, the
+// snapshot is rewritten and the assertion passes. Failures list the
+// differences by path, and have the path and values of the first as their
+// Failure.Path, Actual, and Expected.
+//
+//	MatchSnapshot(body, "testdata/get-user.json",
+//		Placeholder("data.id", "<id>"), SortBy("data", "id"), RoundFloats(2))
+func MatchSnapshot(actual interface{}, expected ...interface{}) (fail string) {
+	usage := "MatchSnapshot expects parseable JSON, a snapshot file path, and optional Normalizers as right-side arguments."
+	if msg := minimally(1, expected); msg != Ok {
+		return FormatFailure(msg, usage, "", "")
+	}
+	path, ok := expected[0].(string)
+	if !ok {
+		return FormatFailure(fmt.Sprintf("Expected the snapshot path to be a string, not a %T.", expected[0]), usage, "", "")
+	}
+	explorer, err := ParseJSON(actual)
+	if err != nil {
+		return err.Error()
+	}
+	doc, err := copyJSON(explorer.Data())
+	if err != nil {
+		return FormatFailure("Can't copy the actual JSON for normalization.", err.Error(), "", "")
+	}
+	for i, arg := range expected[1:] {
+		normalize, ok := arg.(Normalizer)
+		if !ok {
+			return FormatFailure(fmt.Sprintf("Expected right-side argument %d to be a Normalizer, not a %T.", i+1, arg), usage, "", "")
+		}
+		doc = normalize(doc)
+	}
+	content, _, err := prettyJSON(doc)
+	if err != nil {
+		return FormatFailure("Can't encode the normalized snapshot.", err.Error(), "", "")
+	}
+
+	stored, fail := storedFile("snapshot", path, content)
+	if fail != Ok || UpdateGolden {
+		return fail
+	}
+	var storedDoc interface{}
+	if err = json.Unmarshal(stored, &storedDoc); err != nil {
+		return FormatFailure(fmt.Sprintf("Snapshot %s isn't valid JSON.", path), err.Error(), "", "")
+	}
+	// compare the round-tripped document so numbers have the same types
+	doc, _ = copyJSON(doc)
+	diffs := diffJSON("", storedDoc, doc)
+	if len(diffs) == 0 {
+		return Ok
+	}
+	lines := make([]string, len(diffs))
+	for i, d := range diffs {
+		lines[i] = d.line
+	}
+	return Failure{
+		Short:    fmt.Sprintf("Normalized JSON differs from snapshot %s at %d path(s).", path, len(diffs)),
+		Long:     strings.Join(lines, "\n"),
+		Details:  "NORMALIZED ACTUAL:\n" + string(content),
+		Path:     diffs[0].path,
+		Actual:   diffs[0].got,
+		Expected: diffs[0].want,
+	}.String()
+}
+
+// copyJSON deep copies a decoded JSON document.
+func copyJSON(doc interface{}) (result interface{}, err error) {
+	data, err := json.Marshal(doc)
+	if err == nil {
+		err = json.Unmarshal(data, &result)
+	}
+	return
+}
+
+// splitPath turns "a.b.c" into its segments; "" is the root.
+func splitPath(path string) []string {
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, ".")
+}
+
+// rewritePath replaces the values at path with fn(value).
+func rewritePath(doc interface{}, path []string, fn func(interface{}) interface{}) interface{} {
+	if len(path) == 0 {
+		return fn(doc)
+	}
+	seg, rest := path[0], path[1:]
+	switch x := doc.(type) {
+	case map[string]interface{}:
+		for k, v := range x {
+			if seg == "*" || seg == k {
+				x[k] = rewritePath(v, rest, fn)
+			}
+		}
+	case []interface{}:
+		if i, err := strconv.Atoi(seg); err == nil {
+			if i >= 0 && i < len(x) {
+				x[i] = rewritePath(x[i], rest, fn)
+			}
+			break
+		}
+		if seg == "*" {
+			path = rest
+		}
+		for i := range x {
+			x[i] = rewritePath(x[i], path, fn)
+		}
+	}
+	return doc
+}
+
+// fieldOf gets a field from an object, or nil.
+func fieldOf(v interface{}, key string) interface{} {
+	if m, ok := v.(map[string]interface{}); ok {
+		return m[key]
+	}
+	return nil
+}
+
+// lessJSON orders decoded JSON values: numbers numerically, anything else by
+// its text.
+func lessJSON(a, b interface{}) bool {
+	af, aNum := a.(float64)
+	bf, bNum := b.(float64)
+	if aNum && bNum {
+		return af < bf
+	}
+	return fmt.Sprint(a) < fmt.Sprint(b)
+}
+
+// jsonDiff is a difference found by diffJSON, with the values at its path;
+// want or got is nil where the path is missing.
+type jsonDiff struct {
+	path      string
+	line      string // e.g. "~ data.0.score: 0.67 => 0.5"
+	want, got interface{}
+}
+
+// diffJSON lists the paths where two decoded JSON documents differ.
+func diffJSON(path string, want, got interface{}) (diffs []jsonDiff) {
+	join := func(key string) string {
+		if path == "" {
+			return key
+		}
+		return path + "." + key
+	}
+	show := func(v interface{}) string {
+		buf := &bytes.Buffer{}
+		enc := json.NewEncoder(buf)
+		enc.SetEscapeHTML(false)
+		enc.Encode(v)
+		return strings.TrimSpace(buf.String())
+	}
+	where := path
+	if where == "" {
+		where = "(root)"
+	}
+
+	switch w := want.(type) {
+	case map[string]interface{}:
+		g, ok := got.(map[string]interface{})
+		if !ok {
+			break
+		}
+		var keys []string
+		for k := range w {
+			keys = append(keys, k)
+		}
+		for k := range g {
+			if _, ok := w[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			wv, inWant := w[k]
+			gv, inGot := g[k]
+			switch {
+			case !inGot:
+				diffs = append(diffs, jsonDiff{join(k), fmt.Sprintf("- %s: %s", join(k), show(wv)), wv, nil})
+			case !inWant:
+				diffs = append(diffs, jsonDiff{join(k), fmt.Sprintf("+ %s: %s", join(k), show(gv)), nil, gv})
+			default:
+				diffs = append(diffs, diffJSON(join(k), wv, gv)...)
+			}
+		}
+		return
+	case []interface{}:
+		g, ok := got.([]interface{})
+		if !ok {
+			break
+		}
+		for i := 0; i < len(w) || i < len(g); i++ {
+			key := strconv.Itoa(i)
+			switch {
+			case i >= len(g):
+				diffs = append(diffs, jsonDiff{join(key), fmt.Sprintf("- %s: %s", join(key), show(w[i])), w[i], nil})
+			case i >= len(w):
+				diffs = append(diffs, jsonDiff{join(key), fmt.Sprintf("+ %s: %s", join(key), show(g[i])), nil, g[i]})
+			default:
+				diffs = append(diffs, diffJSON(join(key), w[i], g[i])...)
+			}
+		}
+		return
+	}
+	if !reflect.DeepEqual(want, got) {
+		diffs = append(diffs, jsonDiff{path, fmt.Sprintf("~ %s: %s => %s", where, show(want), show(got)), want, got})
+	}
+	return
+}
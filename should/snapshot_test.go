@@ -0,0 +1,57 @@
+package should
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const volatileResponse = `{
+	"meta": {"etag": "%s", "took": %s},
+	"data": [
+		{"id": "%s", "name": "beta", "score": 0.3333333},
+		{"id": "%s", "name": "alpha", "score": 0.6666666}
+	]
+}`
+
+func TestMatchSnapshot(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "users.json")
+	rules := []interface{}{
+		path,
+		Placeholder("meta.etag", "<etag>"),
+		Placeholder("meta.took", 0),
+		Placeholder("data.id", "<id>"),
+		SortBy("data", "name"),
+		RoundFloats(2),
+	}
+	first := fmt.Sprintf(volatileResponse, "abc", "12", "1", "2")
+	second := fmt.Sprintf(volatileResponse, "def", "34", "3", "4")
+
+	Fails(t, "Missing snapshots fail", MatchSnapshot, first, rules...)
+	UpdateGolden = true
+	Passes(t, "Updating writes the snapshot", MatchSnapshot, first, rules...)
+	UpdateGolden = false
+	Passes(t, "Snapshots ignore normalized values", MatchSnapshot, second, rules...)
+
+	stored, _ := ioutil.ReadFile(path)
+	Passes(t, "Snapshots are stored normalized", ContainSubstring, string(stored), `"id": "<id>"`)
+	Passes(t, "Snapshots are stored sorted", ContainSubstring, string(stored), `"name": "alpha",`+"\n      \"score\": 0.67")
+
+	changed := `{"meta": {"etag": "x", "took": 1}, "data": [{"id": "9", "name": "alpha", "score": 0.5, "extra": true}]}`
+	fail := MatchSnapshot(changed, rules...)
+	short, long, _, _ := ParseFailure(fail)
+	Passes(t, "Differences are counted", StartWith, short, "Normalized JSON differs from snapshot")
+	Passes(t, "Changed values are listed by path", ContainSubstring, long, "~ data.0.score: 0.67 => 0.5")
+	Passes(t, "Added values are listed by path", ContainSubstring, long, "+ data.0.extra: true")
+	Passes(t, "Removed values are listed by path", ContainSubstring, long, `- data.1: {"id":"<id>","name":"beta","score":0.33}`)
+	f := AsFailure(fail)
+	Passes(t, "Failures have the first path", Equal, f.Path, "data.0.extra")
+	Passes(t, "Failures have the first values", Resemble, []interface{}{f.Actual, f.Expected}, []interface{}{true, nil})
+}
@@ -0,0 +1,315 @@
+package should
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// StructOption adjusts how MatchStruct compares values.
+type StructOption func(*structMatcher)
+
+// Field checks the values at path with an assertion instead of comparing
+// them with the expected value. Paths look like ".Orders[2].Lines[0].Price";
+// "[*]" matches any index or map key, and a bare name such as "Price" matches
+// a field of that name anywhere.
+//
+//	MatchStruct(want, Field(".CreatedAt", BeChronological), Field("ID", NotBeBlank))
+func Field(path string, a Assertion, expected ...interface{}) StructOption {
+	return func(m *structMatcher) {
+		m.fields = append(m.fields, fieldAssertion{pattern: pathPattern(path), part: Bind(a, expected...)})
+	}
+}
+
+// IgnoreFields skips the values at the given paths, written as for Field.
+func IgnoreFields(paths ...string) StructOption {
+	return func(m *structMatcher) {
+		for _, p := range paths {
+			m.ignored = append(m.ignored, pathPattern(p))
+		}
+	}
+}
+
+// IgnoreUnexported skips the unexported fields of structs, which MatchStruct
+// otherwise compares.
+func IgnoreUnexported() StructOption {
+	return func(m *structMatcher) {
+		m.unexported = true
+	}
+}
+
+// MatchStruct returns an assertion that walks actual and expected (structs,
+// maps, slices, arrays, and pointers to them) and passes if they match.
+// Where expected holds an Assertion or a Bound (in an interface{} field, map,
+// or slice), the actual value there is checked with it instead. Values with an
+// Equal method, such as time.Time, are compared with it. Nil and empty slices
+// and maps match. Failures list every mismatch by its path:
+//
+//	.Orders[2].Lines[0].Price: expected 9.99, got 10.5
+//
+// and have the path and values of the first as their Failure.Path, Actual,
+// and Expected.
+//
+// A pointer given as actual matches a non-pointer expected value it points to.
+//
+//	Assert(t, order, MatchStruct(Order{ID: "1", Lines: lines}, IgnoreFields("UpdatedAt")))
+func MatchStruct(expected interface{}, opts ...StructOption) Assertion {
+	m := &structMatcher{}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return func(actual interface{}, more ...interface{}) (fail string) {
+		if msg := exactly(0, more); msg != Ok {
+			return FormatFailure(msg, "MatchStruct takes its expected value when it's built.", "", "")
+		}
+		act, exp := reflect.ValueOf(actual), reflect.ValueOf(expected)
+		if act.Kind() == reflect.Ptr && exp.IsValid() && exp.Kind() != reflect.Ptr && !act.IsNil() {
+			act = act.Elem()
+		}
+		var mismatches []mismatch
+		m.match("", act, exp, &mismatches, map[visit]bool{})
+		if len(mismatches) == 0 {
+			return Ok
+		}
+		noun := "differences"
+		if len(mismatches) == 1 {
+			noun = "difference"
+		}
+		lines := make([]string, len(mismatches))
+		for i, mm := range mismatches {
+			lines[i] = pathName(mm.path) + ": " + mm.text
+		}
+		first := mismatches[0]
+		return Failure{
+			Short:    fmt.Sprintf("Expected %T values to match, but found %d %s.", expected, len(mismatches), noun),
+			Long:     strings.Join(lines, "\n"),
+			Path:     first.path,
+			Actual:   valueOf(first.actual),
+			Expected: valueOf(first.expected),
+		}.String()
+	}
+}
+
+// mismatch is a difference found by MatchStruct, with the values at its path.
+// expected is invalid where an assertion failed.
+type mismatch struct {
+	path             string
+	text             string // e.g. "expected 9.99, got 10.5"
+	actual, expected reflect.Value
+}
+
+// fieldAssertion is an assertion given with Field.
+type fieldAssertion struct {
+	pattern *regexp.Regexp
+	part    Bound
+}
+
+// structMatcher holds the options of a MatchStruct assertion.
+type structMatcher struct {
+	fields     []fieldAssertion
+	ignored    []*regexp.Regexp
+	unexported bool
+}
+
+// pathPattern compiles a Field or IgnoreFields path.
+func pathPattern(path string) *regexp.Regexp {
+	expr := strings.Replace(regexp.QuoteMeta(path), `\[\*\]`, `\[[^\]]*\]`, -1)
+	if strings.HasPrefix(path, ".") || strings.HasPrefix(path, "[") {
+		return regexp.MustCompile("^" + expr + "$")
+	}
+	return regexp.MustCompile(`(^|\.)` + expr + "$")
+}
+
+// visit is a pair of pointers compared by match, with their type and, for
+// maps and slices, their lengths, so that slices sharing a backing array
+// but of different lengths are told apart.
+type visit struct {
+	act, exp       uintptr
+	actLen, expLen int
+	typ            reflect.Type
+}
+
+// match compares act with exp at path, appending any mismatches. Pairs of
+// pointers, maps, or slices already in seen are taken to match.
+func (m *structMatcher) match(path string, act, exp reflect.Value, mismatches *[]mismatch, seen map[visit]bool) {
+	for _, p := range m.ignored {
+		if p.MatchString(path) {
+			return
+		}
+	}
+	for _, f := range m.fields {
+		if f.pattern.MatchString(path) {
+			m.assert(path, act, f.part, mismatches)
+			return
+		}
+	}
+	if part, ok := asBound(exp); ok {
+		m.assert(path, act, part, mismatches)
+		return
+	}
+	differ := func(format string, args ...interface{}) {
+		*mismatches = append(*mismatches, mismatch{path, fmt.Sprintf(format, args...), act, exp})
+	}
+	if !act.IsValid() || !exp.IsValid() {
+		if act.IsValid() != exp.IsValid() {
+			differ("expected %s, got %s", show(exp), show(act))
+		}
+		return
+	}
+	if act.Type() != exp.Type() {
+		differ("expected a %s, got a %s: %s", exp.Type(), act.Type(), show(act))
+		return
+	}
+	if equal, ok := equalMethod(act, exp); ok {
+		if !equal {
+			differ("expected %s, got %s", show(exp), show(act))
+		}
+		return
+	}
+	switch exp.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice:
+		if !act.IsNil() && !exp.IsNil() {
+			v := visit{act: act.Pointer(), exp: exp.Pointer(), typ: exp.Type()}
+			if exp.Kind() != reflect.Ptr {
+				v.actLen, v.expLen = act.Len(), exp.Len()
+			}
+			if seen[v] {
+				return
+			}
+			seen[v] = true
+		}
+	}
+	switch exp.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if act.IsNil() || exp.IsNil() {
+			if act.IsNil() != exp.IsNil() {
+				differ("expected %s, got %s", show(exp), show(act))
+			}
+			return
+		}
+		m.match(path, act.Elem(), exp.Elem(), mismatches, seen)
+	case reflect.Struct:
+		for i := 0; i < exp.NumField(); i++ {
+			f := exp.Type().Field(i)
+			if f.PkgPath != "" && m.unexported {
+				continue
+			}
+			m.match(path+"."+f.Name, act.Field(i), exp.Field(i), mismatches, seen)
+		}
+	case reflect.Slice, reflect.Array:
+		if act.Len() != exp.Len() {
+			differ("expected %d elements, got %d", exp.Len(), act.Len())
+		}
+		for i := 0; i < act.Len() && i < exp.Len(); i++ {
+			m.match(fmt.Sprintf("%s[%d]", path, i), act.Index(i), exp.Index(i), mismatches, seen)
+		}
+	case reflect.Map:
+		keys := append(exp.MapKeys(), act.MapKeys()...)
+		sort.Slice(keys, func(i, j int) bool { return show(keys[i]) < show(keys[j]) })
+		for i, k := range keys {
+			if i > 0 && show(k) == show(keys[i-1]) {
+				continue
+			}
+			keyPath := fmt.Sprintf("%s[%s]", path, show(k))
+			a, e := act.MapIndex(k), exp.MapIndex(k)
+			switch {
+			case !e.IsValid():
+				*mismatches = append(*mismatches, mismatch{keyPath, "unexpected, got " + show(a), a, e})
+			case !a.IsValid():
+				*mismatches = append(*mismatches, mismatch{keyPath, "missing, expected " + show(e), a, e})
+			default:
+				m.match(keyPath, a, e, mismatches, seen)
+			}
+		}
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		if act.Pointer() != exp.Pointer() {
+			differ("expected %s, got %s", show(exp), show(act))
+		}
+	default:
+		if show(act) != show(exp) {
+			differ("expected %s, got %s", show(exp), show(act))
+		}
+	}
+}
+
+// assert checks act with part, appending its failure, if any.
+func (m *structMatcher) assert(path string, act reflect.Value, part Bound, mismatches *[]mismatch) {
+	var actual interface{}
+	if act.IsValid() {
+		if !act.CanInterface() {
+			*mismatches = append(*mismatches, mismatch{path: path, text: "can't check an unexported field with " + part.String()})
+			return
+		}
+		actual = act.Interface()
+	}
+	if fail := part.Assertion(actual, part.Expected...); fail != Ok {
+		f := AsFailure(fail)
+		text := fmt.Sprintf("%s failed: %s", part, indent(f.Short, "   "))
+		if f.Long != "" {
+			text += "\n   " + indent(f.Long, "   ")
+		}
+		*mismatches = append(*mismatches, mismatch{path: path, text: text, actual: act})
+	}
+}
+
+// asBound returns the assertion held by an expected value, if any.
+func asBound(exp reflect.Value) (Bound, bool) {
+	if !exp.IsValid() || !exp.CanInterface() {
+		return Bound{}, false
+	}
+	switch x := exp.Interface().(type) {
+	case Bound:
+		return x, true
+	case Assertion:
+		return Bound{Assertion: x}, x != nil
+	case func(interface{}, ...interface{}) string:
+		return Bound{Assertion: x}, x != nil
+	}
+	return Bound{}, false
+}
+
+// equalMethod compares act and exp with their type's Equal method, if it has
+// one taking the same type and returning a bool.
+func equalMethod(act, exp reflect.Value) (equal, ok bool) {
+	if !act.CanInterface() || !exp.CanInterface() {
+		return false, false
+	}
+	method := act.MethodByName("Equal")
+	if !method.IsValid() {
+		return false, false
+	}
+	mt := method.Type()
+	if mt.NumIn() != 1 || mt.NumOut() != 1 || mt.In(0) != exp.Type() || mt.Out(0).Kind() != reflect.Bool {
+		return false, false
+	}
+	return method.Call([]reflect.Value{exp})[0].Bool(), true
+}
+
+// pathName names the root path.
+func pathName(path string) string {
+	if path == "" {
+		return "(value)"
+	}
+	return path
+}
+
+// valueOf gives the value held by v for a Failure, if it can.
+func valueOf(v reflect.Value) interface{} {
+	if !v.IsValid() || !v.CanInterface() {
+		return nil
+	}
+	return v.Interface()
+}
+
+// show formats a value for a mismatch, including unexported ones.
+func show(v reflect.Value) string {
+	if !v.IsValid() {
+		return "nil"
+	}
+	if v.Kind() == reflect.String {
+		return fmt.Sprintf("%q", v.String())
+	}
+	return fmt.Sprintf("%#v", v)
+}
@@ -0,0 +1,81 @@
+package should
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type line struct {
+	SKU   string
+	Price float64
+}
+
+type order struct {
+	ID      string
+	Placed  time.Time
+	Lines   []line
+	Meta    map[string]interface{}
+	Notes   interface{}
+	version int
+}
+
+type twoSlices struct {
+	A, B []int
+}
+
+type node struct {
+	Name string
+	Next *node
+}
+
+func TestMatchStruct(t *testing.T) {
+	placed := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	want := order{ID: "1", Placed: placed, Lines: []line{{"a", 1.5}, {"b", 2}}, Meta: map[string]interface{}{"n": 1}, version: 2}
+	got := want
+	got.Placed = placed.In(time.FixedZone("EST", -5*60*60))
+	got.Lines = []line{{"a", 1.5}, {"b", 2}}
+
+	Passes(t, "Equal structs match", MatchStruct(want), got)
+	Passes(t, "Pointers match what they point to", MatchStruct(want), &got)
+	Passes(t, "Nil and empty slices match", MatchStruct(order{}), order{Lines: []line{}})
+
+	got.Lines[1].Price = 2.5
+	got.version = 3
+	short, long, _, _ := ParseFailure(MatchStruct(want)(got))
+	Passes(t, "Short counts differences", Equal, short, "Expected should.order values to match, but found 2 differences.")
+	Passes(t, "Long names paths", Equal, long, ".Lines[1].Price: expected 2, got 2.5\n.version: expected 2, got 3")
+	f := AsFailure(MatchStruct(want)(got))
+	Passes(t, "Failures have the first path", Equal, f.Path, ".Lines[1].Price")
+	Passes(t, "Failures have the first values", Resemble, []interface{}{f.Actual, f.Expected}, []interface{}{2.5, 2.0})
+
+	Passes(t, "Fields can be ignored", MatchStruct(want, IgnoreFields(".Lines[*].Price"), IgnoreUnexported()), got)
+	Passes(t, "Bare names match anywhere", MatchStruct(want, IgnoreFields("Price", "version")), got)
+	Passes(t, "Fields can be asserted", MatchStruct(want, Field("Price", BeGreaterThan, 1.0), IgnoreUnexported()), got)
+	Fails(t, "Field assertions can fail", MatchStruct(want, Field(".ID", Equal, "2"), IgnoreFields("Price", "version")), got)
+
+	want.Notes = Bind(StartWith, "rush")
+	want.Meta = map[string]interface{}{"n": Bind(BeGreaterThan, 0), "gone": true}
+	got.Notes = "rush order"
+	got.Meta = map[string]interface{}{"n": 3, "new": true}
+	_, long, _, _ = ParseFailure(MatchStruct(want, IgnoreFields("Price", "version"))(got))
+	lines := strings.Split(long, "\n")
+	Passes(t, "Map keys can be missing", Equal, lines[0], `.Meta["gone"]: missing, expected true`)
+	Passes(t, "Map keys can be unexpected", Equal, lines[1], `.Meta["new"]: unexpected, got true`)
+	Passes(t, "Assertions in expected values are run", Equal, len(lines), 2)
+
+	Fails(t, "Types must match", MatchStruct(want), line{})
+	Fails(t, "Expected args are rejected", MatchStruct(want), got, 1)
+
+	a, b := &node{Name: "a"}, &node{Name: "a"}
+	a.Next, b.Next = a, b
+	Passes(t, "Cyclic values match", MatchStruct(a), b)
+	b.Next = &node{Name: "b"}
+	b.Next.Next = b
+	_, long, _, _ = ParseFailure(MatchStruct(a)(b))
+	Passes(t, "Cyclic values can differ", Equal, long, `.Next.Name: expected "a", got "b"`)
+
+	s, e := []int{1, 2, 3}, []int{1}
+	_, long, _, _ = ParseFailure(MatchStruct(twoSlices{A: e[:1], B: e[:1]})(twoSlices{A: s[:1], B: s[:3]}))
+	Passes(t, "Slices sharing a backing array are each compared", Equal, long, ".B: expected 1 elements, got 3")
+}
@@ -0,0 +1,171 @@
+package gotest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// SlowestKept is how many of the slowest assertions Stats remembers.
+const SlowestKept = 10
+
+// AssertionStats counts the calls to one assertion function.
+type AssertionStats struct {
+	Assertion string        `json:"assertion"`
+	Calls     int           `json:"calls"`
+	Passed    int           `json:"passed"`
+	Failed    int           `json:"failed"`
+	Total     time.Duration `json:"totalNanoseconds"`
+	Max       time.Duration `json:"maxNanoseconds"`
+}
+
+// PerTestStats counts the assertions made by one test.
+type PerTestStats struct {
+	Test   string `json:"test"`
+	Calls  int    `json:"calls"`
+	Passed int    `json:"passed"`
+	Failed int    `json:"failed"`
+}
+
+// SlowAssertion describes one expensive assertion call.
+type SlowAssertion struct {
+	Assertion string        `json:"assertion"`
+	Test      string        `json:"test"`
+	Location  string        `json:"location"`
+	Duration  time.Duration `json:"nanoseconds"`
+}
+
+// Stats summarizes every assertion made during a package run.
+type Stats struct {
+	Total        int               `json:"total"`
+	Passed       int               `json:"passed"`
+	Failed       int               `json:"failed"`
+	ByAssertion  []*AssertionStats `json:"byAssertion"`
+	ByTest       []*PerTestStats   `json:"byTest"`
+	Slowest      []SlowAssertion   `json:"slowest"`
+	assertionMap map[string]*AssertionStats
+	testMap      map[string]*PerTestStats
+}
+
+var (
+	statsMu sync.Mutex
+	stats   = newStats()
+)
+
+func newStats() *Stats {
+	return &Stats{
+		assertionMap: make(map[string]*AssertionStats),
+		testMap:      make(map[string]*PerTestStats),
+	}
+}
+
+// tally counts an assertion result.
+func tally(r *Result) {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+	stats.add(r)
+}
+
+func (s *Stats) add(r *Result) {
+	a, ok := s.assertionMap[r.Assertion]
+	if !ok {
+		a = &AssertionStats{Assertion: r.Assertion}
+		s.assertionMap[r.Assertion] = a
+		s.ByAssertion = append(s.ByAssertion, a)
+	}
+	t, ok := s.testMap[r.Test]
+	if !ok {
+		t = &PerTestStats{Test: r.Test}
+		s.testMap[r.Test] = t
+		s.ByTest = append(s.ByTest, t)
+	}
+	s.Total++
+	a.Calls++
+	t.Calls++
+	if r.Passed {
+		s.Passed++
+		a.Passed++
+		t.Passed++
+	} else {
+		s.Failed++
+		a.Failed++
+		t.Failed++
+	}
+	a.Total += r.Duration
+	if r.Duration > a.Max {
+		a.Max = r.Duration
+	}
+
+	// keep the slowest calls, slowest first
+	i := sort.Search(len(s.Slowest), func(i int) bool { return s.Slowest[i].Duration < r.Duration })
+	if i >= SlowestKept {
+		return
+	}
+	slow := SlowAssertion{Assertion: r.Assertion, Test: r.Test, Location: r.Location, Duration: r.Duration}
+	s.Slowest = append(s.Slowest, SlowAssertion{})
+	copy(s.Slowest[i+1:], s.Slowest[i:])
+	s.Slowest[i] = slow
+	if len(s.Slowest) > SlowestKept {
+		s.Slowest = s.Slowest[:SlowestKept]
+	}
+}
+
+// AssertionSummary returns a copy of the statistics gathered so far, with
+// assertions sorted by number of calls and tests by name.
+func AssertionSummary() *Stats {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+	result := &Stats{
+		Total:   stats.Total,
+		Passed:  stats.Passed,
+		Failed:  stats.Failed,
+		Slowest: append([]SlowAssertion{}, stats.Slowest...),
+	}
+	for _, a := range stats.ByAssertion {
+		copied := *a
+		result.ByAssertion = append(result.ByAssertion, &copied)
+	}
+	for _, t := range stats.ByTest {
+		copied := *t
+		result.ByTest = append(result.ByTest, &copied)
+	}
+	sort.SliceStable(result.ByAssertion, func(i, j int) bool {
+		return result.ByAssertion[i].Calls > result.ByAssertion[j].Calls
+	})
+	sort.Slice(result.ByTest, func(i, j int) bool { return result.ByTest[i].Test < result.ByTest[j].Test })
+	return result
+}
+
+// WriteSummary lays the statistics out as tables.
+func (s *Stats) WriteSummary(w io.Writer) {
+	fmt.Fprintf(w, "ASSERTIONS: %d total, %d passed, %d failed\n", s.Total, s.Passed, s.Failed)
+	if s.Total == 0 {
+		return
+	}
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(tw, "calls\tpassed\tfailed\ttotal time\tmax time\t  assertion\n")
+	for _, a := range s.ByAssertion {
+		fmt.Fprintf(tw, "%d\t%d\t%d\t%s\t%s\t  %s\n", a.Calls, a.Passed, a.Failed, a.Total, a.Max, a.Assertion)
+	}
+	tw.Flush()
+	fmt.Fprintf(w, "SLOWEST ASSERTIONS:\n")
+	tw = tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, slow := range s.Slowest {
+		fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\n", slow.Duration, slow.Assertion, slow.Test, slow.Location)
+	}
+	tw.Flush()
+}
+
+// WriteJSON writes the statistics to path as JSON.
+func (s *Stats) WriteJSON(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(data, '\n'), 0644)
+}
@@ -0,0 +1,40 @@
+package gotest
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/kindrid/gotest/should"
+)
+
+func TestStats(t *testing.T) {
+	before := AssertionSummary()
+	Assert(t, 1, should.Equal, 1)
+	Group(t, func(g T) {
+		Deny(g, 1, should.Equal, 2)
+	})
+	after := AssertionSummary()
+	Assert(t, after.Total-before.Total, should.Equal, 2)
+
+	s := newStats()
+	for i, d := range []time.Duration{3, 1, 2} {
+		s.add(&Result{Assertion: "should.Equal", Test: "TestA", Passed: i > 0, Duration: d * time.Millisecond})
+	}
+	s.add(&Result{Assertion: "should.BeNil", Test: "TestB", Passed: true, Duration: time.Microsecond})
+	Assert(t, s.Total, should.Equal, 4)
+	Assert(t, s.Failed, should.Equal, 1)
+	Assert(t, *s.assertionMap["should.Equal"], should.Resemble, AssertionStats{
+		Assertion: "should.Equal", Calls: 3, Passed: 2, Failed: 1,
+		Total: 6 * time.Millisecond, Max: 3 * time.Millisecond,
+	})
+	Assert(t, *s.testMap["TestB"], should.Resemble, PerTestStats{Test: "TestB", Calls: 1, Passed: 1})
+	Assert(t, len(s.Slowest), should.Equal, 4)
+	Assert(t, s.Slowest[0].Duration, should.Equal, 3*time.Millisecond)
+	Assert(t, s.Slowest[3].Assertion, should.Equal, "should.BeNil")
+
+	buf := &bytes.Buffer{}
+	s.WriteSummary(buf)
+	Assert(t, buf.String(), should.StartWith, "ASSERTIONS: 4 total, 3 passed, 1 failed\n")
+	Assert(t, buf.String(), should.ContainSubstring, "SLOWEST ASSERTIONS:\n  3ms")
+}
@@ -0,0 +1,118 @@
+package gotest
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"text/tabwriter"
+
+	"github.com/kindrid/gotest/debug"
+	"github.com/kindrid/gotest/should"
+)
+
+// Row is one case for Table: the input, and the assertion and expected values
+// to check the result with.
+type Row struct {
+	Name      string
+	Input     interface{}
+	Assertion should.Assertion
+	Expected  []interface{}
+
+	Parallel bool // run this row with t.Parallel()
+	Focus    bool // run only the focused rows, to debug them
+	Skip     bool // skip this row
+}
+
+// Table runs each row as a subtest named row.Name. subject turns row.Input
+// into the actual value under test; a nil subject uses row.Input itself. When
+// any row fails, a compact table of every row's outcome is logged once all
+// rows (including parallel ones) have finished.
+//
+//	upper := func(in interface{}) interface{} { return strings.ToUpper(in.(string)) }
+//	gotest.Table(t, upper,
+//		gotest.Row{Name: "lower", Input: "abc", Assertion: should.Equal, Expected: []interface{}{"ABC"}},
+//		gotest.Row{Name: "empty", Input: "", Assertion: should.BeBlank, Focus: true},
+//	)
+func Table(t *testing.T, subject func(input interface{}) interface{}, rows ...Row) {
+	location, pkg := debug.CallerSimple(1), debug.CallerPackage(1)
+	focused := 0
+	for _, row := range rows {
+		if row.Focus {
+			focused++
+		}
+	}
+	if focused > 0 {
+		t.Logf("FOCUSED: running only %d of %d table rows (%s)", focused, len(rows), location)
+	}
+
+	var (
+		mu       sync.Mutex
+		outcomes = make([]string, len(rows))
+		failures = make([]string, len(rows))
+		failed   = 0
+	)
+	t.Cleanup(func() {
+		mu.Lock()
+		defer mu.Unlock()
+		if failed > 0 {
+			t.Log(tableSummary(location, rows, outcomes, failures, failed))
+		}
+	})
+
+	for i, row := range rows {
+		i, row := i, row
+		outcomes[i] = "SKIP"
+		t.Run(row.Name, func(t *testing.T) {
+			switch {
+			case row.Skip:
+				t.Skip("skipped by its table row")
+			case focused > 0 && !row.Focus:
+				t.Skip("skipped because other table rows are focused")
+			}
+			if row.Parallel {
+				t.Parallel()
+			}
+			actual := row.Input
+			if subject != nil {
+				actual = subject(row.Input)
+			}
+			r := evaluateRow(t, pkg, fmt.Sprintf("%s[%s]", location, row.Name), actual, row.Assertion, row.Expected)
+
+			mu.Lock()
+			outcomes[i] = "PASS"
+			if !r.Passed {
+				outcomes[i] = "FAIL"
+				failures[i] = strings.SplitN(r.Short, "\n", 2)[0]
+				failed++
+			}
+			mu.Unlock()
+			conclude(t, r)
+		})
+	}
+}
+
+// evaluateRow runs an assertion for a row of Table or RunChecks. Rows run in
+// closures in this package, so the row's location and the package that
+// re-runs it are found by the runner before its subtests start.
+func evaluateRow(t T, pkg, location string, actual interface{}, assertion should.Assertion, expected []interface{}) *Result {
+	r := evaluate(t, 1, false, actual, assertion, expected)
+	r.Location = location
+	if !r.Passed {
+		r.Rerun = RerunCommand(pkg, r.Test)
+	}
+	return r
+}
+
+// tableSummary lays out the outcome of each row.
+func tableSummary(location string, rows []Row, outcomes, failures []string, failed int) string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "TABLE RESULTS (%s): %d of %d rows failed\n", location, failed, len(rows))
+	tw := tabwriter.NewWriter(buf, 0, 4, 2, ' ', 0)
+	for i, row := range rows {
+		fmt.Fprintf(tw, "  %s\t%s\t%s\n", outcomes[i], row.Name, failures[i])
+	}
+	tw.Flush()
+	return buf.String()
+}
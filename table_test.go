@@ -0,0 +1,44 @@
+package gotest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kindrid/gotest/should"
+)
+
+func TestTable(t *testing.T) {
+	upper := func(in interface{}) interface{} { return strings.ToUpper(in.(string)) }
+	Table(t, upper,
+		Row{Name: "lower", Input: "abc", Assertion: should.Equal, Expected: []interface{}{"ABC"}},
+		Row{Name: "mixed", Input: "aBc", Assertion: should.Equal, Expected: []interface{}{"ABC"}, Parallel: true},
+		Row{Name: "broken", Input: "abc", Assertion: should.AlwaysFail, Skip: true},
+	)
+
+	ran := []string{}
+	record := func(in interface{}) interface{} {
+		ran = append(ran, in.(string))
+		return in
+	}
+	t.Run("focus", func(t *testing.T) {
+		Table(t, record,
+			Row{Name: "a", Input: "a", Assertion: should.AlwaysPass},
+			Row{Name: "b", Input: "b", Assertion: should.AlwaysPass, Focus: true},
+			Row{Name: "c", Input: "c", Assertion: should.AlwaysPass},
+		)
+	})
+	Assert(t, ran, should.Resemble, []string{"b"})
+
+	summary := tableSummary("table_test.go:1",
+		[]Row{{Name: "first"}, {Name: "second"}},
+		[]string{"PASS", "FAIL"}, []string{"", "Expected 'A' to equal 'B'"}, 1)
+	Assert(t, summary, should.StartWith, "TABLE RESULTS (table_test.go:1): 1 of 2 rows failed\n")
+	Assert(t, summary, should.ContainSubstring, "  FAIL  second  Expected 'A' to equal 'B'")
+
+	ft := &fakeT{name: "TestTableRerun/x"}
+	r := evaluateRow(ft, "example.com/app", "app_test.go:9[x]", 1, should.Equal, []interface{}{2})
+	Assert(t, r.Location, should.Equal, "app_test.go:9[x]")
+	Assert(t, r.Rerun, should.StartWith, "go test -run '^TestTableRerun$/^x$' example.com/app")
+	r = evaluateRow(ft, "example.com/app", "app_test.go:9[x]", 1, should.Equal, []interface{}{1})
+	Assert(t, r.Rerun, should.Equal, "")
+}
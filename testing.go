@@ -2,9 +2,8 @@ package gotest
 
 import (
 	"flag"
-	"fmt"
+	"time"
 
-	"github.com/davecgh/go-spew/spew"
 	"github.com/kindrid/gotest/debug"
 	"github.com/kindrid/gotest/should"
 )
@@ -44,7 +43,8 @@ var StackDepth int
 var StackLevel int
 
 // Verbosity sets a level of "chattiness" for the tests. It is puporsefully
-// public so tests using this library can manipulate it and check it.
+// public so tests using this library can manipulate it and check it. To change
+// it for a single (possibly parallel) test, use With and a Config instead.
 var Verbosity int
 
 // FailFast halts testing with testing.FailNow() upon the first error. This will
@@ -52,9 +52,63 @@ var Verbosity int
 // `testing.FailNow() to understand its limitations
 var FailFast bool
 
+// ReportJSON names a file that receives every assertion result as a line of
+// JSON (see JSONLinesReporter). Blank disables.
+var ReportJSON string
+
+// ReportJUnit names a JUnit XML file written at the end of the package run by
+// Main or Run. Blank disables.
+var ReportJUnit string
+
+// SkipPending makes Later skip the rest of the test it's called from.
+var SkipPending bool
+
+// StrictPending makes Later fail the test it's called from. It takes
+// precedence over SkipPending.
+var StrictPending bool
+
+// ReportPending names a file that receives the items noted with Later as
+// JSON at the end of the package run by Main or Run. Blank disables.
+var ReportPending string
+
+// ShowStats makes Main print a summary of the assertions made during the run.
+var ShowStats bool
+
+// ReportStats names a file that receives assertion statistics as JSON at the
+// end of the package run by Main or Run. Blank disables.
+var ReportStats string
+
+// DiffContext is the number of unchanged lines shown around each change in
+// the diffs added to failures at the Long verbosity level.
+var DiffContext = 3
+
+// Color controls ANSI coloring of diffs: "always", "never", or "auto" (the
+// default), which colors when stdout is a terminal and NO_COLOR is unset.
+var Color = "auto"
+
+// MaxInspectBytes, MaxInspectDepth, MaxInspectElements, and MaxInspectString
+// limit the values shown by Inspectv and in failures: the bytes shown per
+// value, the levels of nesting, the elements shown per array, slice, or map
+// (lines of a hex dump, for byte slices), and the bytes shown per string.
+// Elided parts are marked, e.g. "... 148 more elements ...". Zero or less
+// removes a limit.
+var (
+	MaxInspectBytes    = 16384
+	MaxInspectDepth    = 10
+	MaxInspectElements = 50
+	MaxInspectString   = 1024
+)
+
 // RegisterFlags adds CLI flags to tailor these testing parameters. Call this
 // function within your test code's init(). Use them with gotest -args. For
 // example `gotest . -args -gotest-depth 5`.
+//
+// Every setting can also come from an environment variable (see EnvPrefix) or
+// from a .gotest.yaml file in the package directory or one of its parents (see
+// ConfigFileName), which is handier with `go test ./...` and IDE runners.
+// Precedence is flag > environment > file > built-in default:
+//
+//	GOTEST_VERBOSITY=2 go test ./...
 func RegisterFlags(prefix string) {
 	if prefix == "" {
 		prefix = "gotest-"
@@ -64,87 +118,165 @@ func RegisterFlags(prefix string) {
 	if flag.Lookup(depthFlagName) != nil {
 		return // prevent multiple registrations
 	}
-	flag.IntVar(&StackDepth, depthFlagName, 0, "stack trace depth on failure")
-	flag.IntVar(&StackLevel, prefix+"level", 0, "number of stack frames to ignore before printing stack-depth frames")
-	flag.IntVar(&Verbosity, prefix+"verbosity", 0, "verbosity level: -1=silent, 0=short, 1=long, 2=show-actuals, \n\t3=show-expecteds, 4=debug-successes, 5=show-test-internals")
-	flag.BoolVar(&FailFast, prefix+"failfast", false, "cause tests to exit with errorcode=1 after the first assertion failure")
+	var names []string
+	setting := func(name string) string {
+		names = append(names, name)
+		registeredFlags[prefix+name] = true
+		return prefix + name
+	}
+	flag.IntVar(&StackDepth, setting("depth"), 0, "stack trace depth on failure")
+	flag.IntVar(&StackLevel, setting("level"), 0, "number of stack frames to ignore before printing stack-depth frames")
+	flag.IntVar(&Verbosity, setting("verbosity"), 0, "verbosity level: -1=silent, 0=short, 1=long, 2=show-actuals, \n\t3=show-expecteds, 4=debug-successes, 5=show-test-internals")
+	flag.BoolVar(&FailFast, setting("failfast"), false, "cause tests to exit with errorcode=1 after the first assertion failure")
+	flag.BoolVar(&AbortOnFailure, setting("abort"), false, "abort the package run after the first assertion failure, skipping the remaining tests")
+	flag.StringVar(&ReportJSON, setting("report-json"), "", "append each assertion result as a line of JSON to this file")
+	flag.StringVar(&ReportJUnit, setting("report-junit"), "", "write a JUnit XML report of assertion results to this file (needs gotest.Main in TestMain)")
+	flag.BoolVar(&should.UpdateGolden, setting("update"), false, "rewrite golden files, snapshots, and benchmark baselines with actual values instead of comparing them")
+	flag.Float64Var(&BenchTolerance, setting("bench-tolerance"), 10, "percent worse than their baselines that gotest.AssertB lets benchmarks perform")
+	flag.BoolVar(&SkipPending, setting("skip-pending"), false, "skip the rest of tests that call gotest.Later")
+	flag.BoolVar(&StrictPending, setting("strict-pending"), false, "fail tests that call gotest.Later")
+	flag.StringVar(&ReportPending, setting("report-pending"), "", "write items noted with gotest.Later to this file as JSON (needs gotest.Main in TestMain)")
+	flag.BoolVar(&ShowStats, setting("stats"), false, "print a summary of assertion counts and timings (needs gotest.Main in TestMain)")
+	flag.StringVar(&ReportStats, setting("report-stats"), "", "write assertion counts and timings to this file as JSON (needs gotest.Main in TestMain)")
+	flag.IntVar(&DiffContext, setting("diff-context"), 3, "unchanged lines shown around each change in failure diffs")
+	flag.StringVar(&Color, setting("color"), "auto", "color failure diffs: auto, always, or never")
+	flag.IntVar(&MaxInspectBytes, setting("inspect-bytes"), 16384, "bytes shown per inspected value, 0 for no limit")
+	flag.IntVar(&MaxInspectDepth, setting("inspect-depth"), 10, "levels of nesting shown in inspected values, 0 for no limit")
+	flag.IntVar(&MaxInspectElements, setting("inspect-elements"), 50, "elements shown per array, slice, or map in inspected values, 0 for no limit")
+	flag.IntVar(&MaxInspectString, setting("inspect-string"), 1024, "bytes shown per string in inspected values, 0 for no limit")
+	flag.IntVar(&should.MaxElementFailures, setting("element-failures"), 10, "failing elements described by should.Each, Any, and None, 0 for no limit")
+	applySettingSources(prefix, names)
 }
 
 // Vocal makes an easy way to gate operations by verbosity level. It returns true if Verbosity is < minLevel.
+// Use Settings(t).Vocal to honor Overrides attached to a test.
 func Vocal(minLevel int) bool {
-	return Verbosity >= minLevel
+	return Defaults().Vocal(minLevel)
 }
 
-// Sprintv formats a string if Verbosity >= minLevel, otherwise returns ""
+// Sprintv formats a string if Verbosity >= minLevel, otherwise returns "".
+// Use Settings(t).Sprintv to honor Overrides attached to a test.
 func Sprintv(minLevel int, format string, args ...interface{}) string {
-
-	if !Vocal(minLevel) {
-		return ""
-	}
-	return fmt.Sprintf(format, args...)
+	return Defaults().Sprintv(minLevel, format, args...)
 }
 
 // Inspectv returns a detailed introspection of objects if Verbosity >= minLevel.
+// Use Settings(t).Inspectv to honor Overrides attached to a test.
 func Inspectv(minLevel int, label string, inspected ...interface{}) (result string) {
-	if !Vocal(minLevel) {
-		return
-	}
-	if label != "" {
-		result = fmt.Sprintf("%s: \n", label)
-	}
-	for _, x := range inspected {
-		explorer, err := should.ParseJSON(x)
-		if err == nil {
-			result += explorer.String() + "\n"
-		} else {
-			result += spew.Sdump(x)
-		}
-	}
-	return
+	return Defaults().Inspectv(minLevel, label, inspected...)
 }
 
 // Assert wraps any standard Assertion for use with Go's std.testing library.
 func Assert(t T, actual interface{}, assertion should.Assertion, expected ...interface{}) {
-
-	fail := assertion(actual, expected...)
-	if fail != "" || Verbosity >= Debug {
-		terseMsg, extraMsg, detailsMsg, metaMsg := should.ParseFailure(fail)
-		msg := ""
-		if StackDepth > 0 {
-			msg += fmt.Sprintf("\nTest Failure Stack Trace: %s\n\n", debug.FormattedCallStack(StackLevel, StackDepth))
-		}
-		name := t.Name()
-		msg += Sprintv(Short, "FAILED %s: %s", name, terseMsg)
-		msg += Sprintv(Long, "\nEXTRA INFO: %s\n", extraMsg+"\nCalls:"+debug.ShortStack(3, 10))
-		msg += Inspectv(Actuals, "\nLEFT-SIDE VALUE (usually actual value-under-test)", actual)
-		msg += Inspectv(Expecteds, "\nRIGHT-SIDE VALUES", expected)
-		if detailsMsg != "" {
-			msg += Sprintv(Debug, "\nDETAILS: %s\n", detailsMsg)
-		}
-		if metaMsg != "" {
-			msg += Sprintv(Insane, "\nINTERNALS (FOR DEBUGGING ASSERTIONS): %s\n", metaMsg)
-		}
-		if FailFast {
-			msg += "\nNOTE: skipping remaining assertions for this test because of --gotest-failfast."
-			t.Error(msg)
-			t.FailNow()
-		} else {
-			msg += "\n"
-			t.Error(msg)
-		}
-	}
+	conclude(t, evaluate(t, 1, false, actual, assertion, expected))
 }
 
 // Deny negates any standard Assertion for use with Go's std.testing library.
-// You may also want to use should.Not()--it will give more accurate reporting.
+// It reports failures just as Assert(t, actual, should.Not(assertion), ...)
+// would, naming the negated assertion.
 func Deny(t T, actual interface{}, assertion should.Assertion, expected ...interface{}) {
+	conclude(t, evaluate(t, 1, true, actual, assertion, expected))
+}
+
+// Require works like Assert but always stops the test when the assertion
+// fails, regardless of FailFast. Use it for preconditions that later
+// assertions depend on, such as a status code check before reading the body.
+func Require(t T, actual interface{}, assertion should.Assertion, expected ...interface{}) {
+	conclude(t, require(evaluate(t, 1, false, actual, assertion, expected)))
+}
+
+// RequireNot works like Deny but always stops the test when the assertion
+// passes, regardless of FailFast.
+func RequireNot(t T, actual interface{}, assertion should.Assertion, expected ...interface{}) {
+	conclude(t, require(evaluate(t, 1, true, actual, assertion, expected)))
+}
+
+// require marks a result as one that stops the test on failure.
+func require(r *Result) *Result {
+	r.Required = true
+	r.FailNow = !r.Passed
+	return r
+}
+
+// evaluate runs an assertion and describes the outcome. skip is the number of
+// frames between evaluate's caller and the test code making the assertion.
+func evaluate(t T, skip int, negate bool, actual interface{}, assertion should.Assertion, expected []interface{}) *Result {
+	return evaluateAs(t, skip+1, should.AssertionName(assertion), negate, actual, assertion, expected)
+}
+
+// evaluateAs works like evaluate for an assertion reported as name.
+func evaluateAs(t T, skip int, name string, negate bool, actual interface{}, assertion should.Assertion, expected []interface{}) *Result {
+	if negate {
+		assertion = should.Not(assertion)
+	}
+	start := time.Now()
 	fail := assertion(actual, expected...)
-	if fail == "" {
-		t.Error("Expected a failure")
+	elapsed := time.Since(start)
+	r := newResult(t, skip+1, name, fail, actual, expected)
+	r.Negated = negate
+	r.Duration = elapsed
+	return r
+}
+
+// newResult describes the outcome of an assertion that has already run. skip
+// works as in evaluate.
+func newResult(t T, skip int, name, fail string, actual interface{}, expected []interface{}) *Result {
+	cfg := Settings(t)
+	r := &Result{
+		Test:      t.Name(),
+		Location:  debug.CallerSimple(skip + 1),
+		Calls:     debug.ShortStack(skip+3, 10),
+		Assertion: name,
+		Passed:    fail == "",
+		Actual:    actual,
+		Expected:  expected,
+		Verbosity: cfg.Verbosity,
+		FailNow:   fail != "" && cfg.FailFast,
+		Time:      time.Now(),
+	}
+	f := should.AsFailure(fail)
+	r.Short, r.Long, r.Details, r.Meta, r.Path = f.Short, f.Long, f.Details, f.Meta, f.Path
+	if fail != "" {
+		r.Rerun = RerunCommand(debug.CallerPackage(skip+1), r.Test)
 	}
+	if fail != "" && cfg.Verbosity >= Long {
+		r.Diff = failureDiff(fail, f, actual, expected, DiffContext)
+	}
+	if cfg.StackDepth > 0 {
+		r.Stack = debug.FormattedCallStack(skip+3+cfg.StackLevel, cfg.StackDepth)
+	}
+	return r
 }
 
-// Later describes pending tests.
-func Later(t T, desc string, ignored ...interface{}) {
-	t.Logf("LATER: %s", desc)
+// conclude counts a result for the statistics (see AssertionSummary) and
+// delivers it. Once another test has aborted the run, t is skipped instead.
+func conclude(t T, r *Result) {
+	if skipAborted(t) {
+		return
+	}
+	tally(r)
+	deliver(t, r)
+}
+
+// deliver reports a result and fails the test if needed. Within a Group, the
+// result is held for the group's combined report instead.
+func deliver(t T, r *Result) {
+	if g, ok := t.(*group); ok {
+		g.add(r)
+		if r.Required && !r.Passed {
+			g.FailNow()
+		}
+		return
+	}
+	report(t, r)
+	if r.Passed {
+		return
+	}
+	if AbortOnFailure {
+		abort(r.Test)
+	}
+	t.Fail()
+	if r.FailNow {
+		t.FailNow()
+	}
 }
@@ -1,6 +1,7 @@
 package gotest
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/kindrid/gotest/should"
@@ -22,3 +23,80 @@ func TestTesting(t *testing.T) {
 	Deny(tDeny, "forced", should.AlwaysPass)
 	Assert(t, tDeny.Failed(), should.BeTrue)
 }
+
+func TestDenyMessages(t *testing.T) {
+	rec := &recorder{}
+	withReporters(func() {
+		Deny(&fakeT{}, "forced", should.Equal, "forced")
+	}, rec)
+	Assert(t, rec.results[0].Assertion, should.Equal, "assertions.ShouldEqual")
+	Assert(t, rec.results[0].Short, should.Equal, "Expected assertions.ShouldEqual to fail, but it passed.")
+	Assert(t, rec.results[0].Long, should.ContainSubstring, `actual:   "forced"`)
+}
+
+// fakeT records what a test reports without stopping anything.
+type fakeT struct {
+	name     string
+	errors   []string
+	logs     []string
+	failed   bool
+	stopped  bool
+	cleanups []func()
+}
+
+func (ft *fakeT) Error(args ...interface{}) {
+	ft.failed = true
+	ft.errors = append(ft.errors, fmt.Sprint(args...))
+}
+
+func (ft *fakeT) Errorf(format string, args ...interface{}) {
+	ft.Error(fmt.Sprintf(format, args...))
+}
+
+func (ft *fakeT) Fail() { ft.failed = true }
+
+func (ft *fakeT) FailNow() {
+	ft.failed = true
+	ft.stopped = true
+}
+
+func (ft *fakeT) Logf(format string, args ...interface{}) {
+	ft.logs = append(ft.logs, fmt.Sprintf(format, args...))
+}
+
+func (ft *fakeT) Name() string { return ft.name }
+
+func (ft *fakeT) Cleanup(fn func()) { ft.cleanups = append(ft.cleanups, fn) }
+
+// finish runs the cleanups, as the end of a test would.
+func (ft *fakeT) finish() {
+	for i := len(ft.cleanups) - 1; i >= 0; i-- {
+		ft.cleanups[i]()
+	}
+	ft.cleanups = nil
+}
+
+func TestRequire(t *testing.T) {
+	// Happy Path
+	Require(t, "forced", should.AlwaysPass)
+	RequireNot(t, "forced", should.AlwaysFail)
+
+	// Failures stop the test even without FailFast.
+	ft := &fakeT{}
+	Require(ft, "forced", should.AlwaysFail)
+	Assert(t, ft.stopped, should.BeTrue)
+	Assert(t, ft.errors[0], should.ContainSubstring, "a required assertion failed")
+	ft = &fakeT{}
+	RequireNot(ft, "forced", should.AlwaysPass)
+	Assert(t, ft.stopped, should.BeTrue)
+
+	// Within a group, a required failure reports the group and stops.
+	ft = &fakeT{}
+	Group(ft, func(g T) {
+		Assert(g, "forced", should.AlwaysFail)
+		Require(g, "forced", should.AlwaysFail)
+	})
+	Assert(t, ft.stopped, should.BeTrue)
+	Assert(t, len(ft.errors), should.Equal, 1)
+	Assert(t, ft.errors[0], should.ContainSubstring, "2 of 2 assertions failed")
+}
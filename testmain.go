@@ -0,0 +1,75 @@
+package gotest
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"testing"
+)
+
+// Main runs a package's tests and exits. Use it as your TestMain so that
+// package-wide features such as report files work:
+//
+//	func TestMain(m *testing.M) {
+//		gotest.RegisterFlags("")
+//		gotest.Main(m)
+//	}
+func Main(m *testing.M) {
+	os.Exit(Run(m))
+}
+
+// Run runs a package's tests like m.Run(), preparing the reporters requested
+// by flags beforehand and writing their reports, and closing reporters that
+// implement io.Closer, afterwards. It also prints a
+// summary of the items noted with Later and, if asked, of the assertions
+// made, and notes whether the run was aborted. It returns the exit code; a
+// report that can't be written turns a passing run into a failing one.
+func Run(m *testing.M) (code int) {
+	if !flag.Parsed() {
+		flag.Parse()
+	}
+
+	var junit *JUnitReporter
+	if ReportJUnit != "" {
+		junit = NewJUnitReporter()
+		AddReporter(junit)
+	}
+
+	code = m.Run()
+
+	fail := func(what string, err error) {
+		fmt.Fprintf(os.Stderr, "gotest: can't write %s: %s\n", what, err)
+		if code == 0 {
+			code = 1
+		}
+	}
+	if junit != nil {
+		if err := junit.WriteFile(ReportJUnit); err != nil {
+			fail("JUnit report", err)
+		}
+	}
+	if err := closeReporters(); err != nil {
+		fail("report", err)
+	}
+	if by := AbortedBy(); by != "" {
+		fmt.Fprintf(os.Stdout, "ABORTED: remaining tests skipped after first failure in %s\n", by)
+	}
+	WritePendingSummary(os.Stdout)
+	if ShowStats || ReportStats != "" {
+		summary := AssertionSummary()
+		if ShowStats {
+			summary.WriteSummary(os.Stdout)
+		}
+		if ReportStats != "" {
+			if err := summary.WriteJSON(ReportStats); err != nil {
+				fail("assertion statistics", err)
+			}
+		}
+	}
+	if ReportPending != "" {
+		if err := WritePendingJSON(ReportPending); err != nil {
+			fail("pending report", err)
+		}
+	}
+	return
+}
@@ -0,0 +1,30 @@
+//go:build go1.18
+// +build go1.18
+
+package gotest
+
+import (
+	"github.com/kindrid/gotest/should"
+)
+
+// That checks actual with a type-safe Matcher, reporting like Assert. The
+// compiler ensures the matcher fits the actual value's type.
+//
+//	gotest.That(t, rsp.StatusCode, should.EqualTo(http.StatusOK))
+func That[V any](t T, actual V, m should.Matcher[V]) {
+	conclude(t, evaluateAs(t, 1, m.Name, false, actual, matched(actual, m), m.Expected))
+}
+
+// RequireThat works like That but always stops the test when the matcher
+// fails, like Require.
+func RequireThat[V any](t T, actual V, m should.Matcher[V]) {
+	conclude(t, require(evaluateAs(t, 1, m.Name, false, actual, matched(actual, m), m.Expected)))
+}
+
+// matched adapts m, already given actual, to the Assertion that evaluateAs
+// runs with m's expected values.
+func matched[V any](actual V, m should.Matcher[V]) should.Assertion {
+	return func(interface{}, ...interface{}) string {
+		return m.Match(actual)
+	}
+}
@@ -0,0 +1,43 @@
+//go:build go1.18
+// +build go1.18
+
+package gotest
+
+import (
+	"testing"
+
+	"github.com/kindrid/gotest/should"
+)
+
+func TestThat(t *testing.T) {
+	That(t, 3, should.EqualTo(3))
+	That(t, []string{"a", "b"}, should.Containing("b"))
+
+	rec := &recorder{}
+	ft := &fakeT{}
+	withReporters(func() {
+		That(ft, "abc", should.EqualTo("abd"))
+		RequireThat(ft, 1, should.GreaterThan(2))
+	}, rec)
+	Assert(t, ft.failed, should.BeTrue)
+	Assert(t, ft.stopped, should.BeTrue)
+	Require(t, len(rec.results), should.Equal, 2)
+	Assert(t, rec.results[0].Assertion, should.Equal, "should.EqualTo")
+	Assert(t, rec.results[0].Location, should.StartWith, "that_test.go:")
+	Assert(t, rec.results[0].Short, should.Equal, "Expected: 'abd'")
+	Assert(t, rec.results[1].Required, should.BeTrue)
+	Assert(t, rec.results[0].Expected, should.Resemble, []interface{}{"abd"})
+
+	// EqualTo failures get the diffs Equal's do
+	long := &fakeT{name: "TestThat/long"}
+	With(long, Overrides{Verbosity: Int(Long)})
+	defer long.finish()
+	rec.results = nil
+	withReporters(func() {
+		That(long, "one\ntwo", should.EqualTo("one\n2"))
+		Assert(long, "one\ntwo", should.Equal, "one\n2")
+	}, rec)
+	Require(t, len(rec.results), should.Equal, 2)
+	Assert(t, rec.results[0].Diff, should.NotBeBlank)
+	Assert(t, rec.results[0].Diff, should.Equal, rec.results[1].Diff)
+}